@@ -0,0 +1,43 @@
+package main
+
+// Arguments to format are: [1]: type name [2]: varint append function [3]: varint read function
+// [4]: integer type of the varint [5]: statement handling numbers that are not values of the type
+// [6]: strict marshaling guard
+const binaryMethods = `
+// MarshalBinary implements the encoding.BinaryMarshaler interface for %[1]s
+func (i %[1]s) MarshalBinary() ([]byte, error) {
+	return i.AppendBinary(nil)
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface for %[1]s
+func (i %[1]s) AppendBinary(b []byte) ([]byte, error) {
+%[6]s	return binary.%[2]s(b, %[4]s(i)), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface for %[1]s
+func (i *%[1]s) UnmarshalBinary(data []byte) error {
+	n, size := binary.%[3]s(data)
+	if size <= 0 || size != len(data) {
+		return fmt.Errorf("%[1]s should be a single varint, got %%x", data)
+	}
+
+	val := %[1]s(n)
+	if %[4]s(val) != n || !val.IsA%[1]s() {
+		%[5]s
+	}
+	*i = val
+	return nil
+}
+`
+
+// buildBinaryMethods generates the binary marshaling methods, encoding the
+// values as varints: zig-zag ones for signed types, plain ones otherwise.
+func (g *Generator) buildBinaryMethods(typeName string, signed bool, opts generateOptions) {
+	guard := strictMarshalGuard(typeName, "return b, %s", opts)
+	invalid := invalidNumberStatement(typeName, "fmt.Sprint(n)", opts)
+	if signed {
+		g.Printf(binaryMethods, typeName, "AppendVarint", "Varint", "int64", invalid, guard)
+		return
+	}
+	g.Printf(binaryMethods, typeName, "AppendUvarint", "Uvarint", "uint64", invalid, guard)
+}
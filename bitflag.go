@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+	"strings"
+)
+
+// Arguments to format are: [1]: type name
+const stringBitflag = `// _%[1]sParts returns the names of the flags set in i. A value with a name
+// of its own is returned as is, otherwise named combinations are preferred
+// over single flags and the bits left over are printed as a number.
+func _%[1]sParts(i %[1]s) []string {
+	if str, ok := _%[1]sMap[i]; ok {
+		return []string{str}
+	}
+	var parts []string
+	rest := i
+	for _, v := range _%[1]sBits {
+		if rest&v == v {
+			parts = append(parts, _%[1]sMap[v])
+			rest &^= v
+		}
+	}
+	if rest != 0 {
+		parts = append(parts, fmt.Sprintf("%[1]s(%%d)", rest))
+	}
+	return parts
+}
+
+func (i %[1]s) String() string {
+	return strings.Join(_%[1]sParts(i), _%[1]sSeparator)
+}
+`
+
+// Arguments to format are: [1]: type name [2]: complete error expression [3]: separator
+// [4]: function name [5]: statements looking up the flag named part
+const stringNameToBitflagMethod = `
+// %[4]s retrieves an enum value from the enum constants string name,
+// or from several of them joined by %[3]q.
+// Throws an error if any of the names is not part of the enum.
+func %[4]s(s string) (%[1]s, error) {
+	if val, ok := _%[1]sNameToValueMap[s]; ok {
+		return val, nil
+	}
+	if s == "" {
+		return 0, nil
+	}
+	var flags %[1]s
+	for _, part := range strings.Split(s, _%[1]sSeparator) {
+		part = strings.TrimSpace(part)
+		%[5]s
+		if !ok {
+			return 0, %[2]s
+		}
+		flags |= val
+	}
+	return flags, nil
+}
+`
+
+// Arguments to format are: [1]: type name
+const bitflagLookup = `val, ok := _%[1]sNameToValueMap[part]
+		if !ok {
+			val, ok = _%[1]sLowerNameToValueMap[strings.ToLower(part)]
+		}`
+
+// Arguments to format are: [1]: type name
+const bitflagLookupExact = `val, ok := _%[1]sNameToValueMap[part]`
+
+// Arguments to format are: [1]: type name
+const stringBelongsMethodMask = `// IsA%[1]s returns "true" if the value only has flags of the enum definition set. "false" otherwise
+func (i %[1]s) IsA%[1]s() bool {
+	return i&^_%[1]sMask == 0
+}
+`
+
+// Arguments to format are: [1]: type name
+const bitflagMethods = `
+// Has returns "true" if all the flags set in flags are also set in i.
+func (i %[1]s) Has(flags %[1]s) bool {
+	return i&flags == flags
+}
+
+// Set returns i with all the flags set in flags added.
+func (i %[1]s) Set(flags %[1]s) %[1]s {
+	return i | flags
+}
+
+// Clear returns i with all the flags set in flags removed.
+func (i %[1]s) Clear(flags %[1]s) %[1]s {
+	return i &^ flags
+}
+
+// Toggle returns i with all the flags set in flags flipped.
+func (i %[1]s) Toggle(flags %[1]s) %[1]s {
+	return i ^ flags
+}
+`
+
+// Arguments to format are: [1]: type name [2]: name of the function decoding strings [3]: strict marshaling guard
+const bitflagJSONMethods = `
+// MarshalJSON implements the json.Marshaler interface for %[1]s
+func (i %[1]s) MarshalJSON() ([]byte, error) {
+%[3]s	parts := _%[1]sParts(i)
+	if parts == nil {
+		parts = []string{}
+	}
+	return json.Marshal(parts)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for %[1]s
+func (i *%[1]s) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("%[1]s should be an array of strings or a string, got %%s", data)
+		}
+		var err error
+		*i, err = %[2]s(s)
+		return err
+	}
+
+	var flags %[1]s
+	for _, name := range names {
+		val, err := %[2]s(name)
+		if err != nil {
+			return err
+		}
+		flags |= val
+	}
+	*i = flags
+	return nil
+}
+`
+
+// buildBitflag generates the variables and String method for a set of bit flags.
+// Values that have a name of their own are found in a map, any other value is
+// decomposed into the named values it is made of.
+func (g *Generator) buildBitflag(runs [][]Value, typeName string, separator string, caseSensitive bool) {
+	g.Printf("\n")
+	g.declareNameVars(runs, typeName, "", caseSensitive)
+	g.declareMapVar(runs, typeName)
+
+	// Combinations are tried before the single flags, the ones covering the
+	// most bits first, so that named combinations are preferred when printing.
+	var flags, mask []string
+	var ordered []Value
+	for _, values := range runs {
+		for _, value := range values {
+			mask = append(mask, value.originalName)
+			if value.value != 0 {
+				ordered = append(ordered, value)
+			}
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return bits.OnesCount64(ordered[i].value) > bits.OnesCount64(ordered[j].value)
+	})
+	for _, value := range ordered {
+		flags = append(flags, value.originalName)
+	}
+	g.Printf("\nconst _%sSeparator = %q\n", typeName, separator)
+	g.Printf("\nconst _%sMask = %s\n", typeName, strings.Join(mask, " | "))
+	g.Printf("\nvar _%sBits = []%s{%s}\n\n", typeName, typeName, strings.Join(flags, ", "))
+	g.Printf(stringBitflag, typeName)
+}
+
+// buildBitflagStringMethods generates the <Type>String function parsing
+// combinations of flags, and its case sensitive <Type>StringStrict version.
+func (g *Generator) buildBitflagStringMethods(typeName, errorCode, separator string, caseSensitive bool) {
+	lookup := bitflagLookup
+	if caseSensitive {
+		lookup = bitflagLookupExact
+	}
+	g.Printf(stringNameToBitflagMethod, typeName, errorCode, separator, typeName+"String", fmt.Sprintf(lookup, typeName))
+	g.Printf(stringNameToBitflagMethod, typeName, errorCode, separator, typeName+"StringStrict", fmt.Sprintf(bitflagLookupExact, typeName))
+}
+
+func (g *Generator) buildBitflagMethods(typeName string) {
+	g.Printf(bitflagMethods, typeName)
+}
+
+func (g *Generator) buildBitflagJSONMethods(typeName string, decode string, guard string) {
+	g.Printf(bitflagJSONMethods, typeName, decode, guard)
+}
@@ -0,0 +1,62 @@
+package main
+
+// Arguments to format are: [1]: type name [2]: name of the function decoding strings [3]: strict marshaling guard
+const bsonMethods = `
+// MarshalBSONValue implements the bson.ValueMarshaler interface for %[1]s
+func (i %[1]s) MarshalBSONValue() (byte, []byte, error) {
+%[3]s	typ, data, err := bson.MarshalValue(i.String())
+	return byte(typ), data, err
+}
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface for %[1]s
+func (i *%[1]s) UnmarshalBSONValue(typ byte, data []byte) error {
+	raw := bson.RawValue{Type: bson.Type(typ), Value: data}
+	s, ok := raw.StringValueOK()
+	if !ok {
+		return fmt.Errorf("%[1]s should be a string, got a %%s", raw.Type)
+	}
+
+	var err error
+	*i, err = %[2]s(s)
+	return err
+}
+`
+
+// Arguments to format are: [1]: type name [2]: statement handling numbers that are not values of the type
+// [3]: strict marshaling guard
+const bsonIntMethods = `
+// MarshalBSONValue implements the bson.ValueMarshaler interface for %[1]s
+func (i %[1]s) MarshalBSONValue() (byte, []byte, error) {
+%[3]s	typ, data, err := bson.MarshalValue(int64(i))
+	return byte(typ), data, err
+}
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface for %[1]s
+func (i *%[1]s) UnmarshalBSONValue(typ byte, data []byte) error {
+	raw := bson.RawValue{Type: bson.Type(typ), Value: data}
+	var n int64
+	if n32, ok := raw.Int32OK(); ok {
+		n = int64(n32)
+	} else if n64, ok := raw.Int64OK(); ok {
+		n = n64
+	} else {
+		return fmt.Errorf("%[1]s should be an integer, got a %%s", raw.Type)
+	}
+
+	val := %[1]s(n)
+	if int64(val) != n || !val.IsA%[1]s() {
+		%[2]s
+	}
+	*i = val
+	return nil
+}
+`
+
+func (g *Generator) buildBSONMethods(typeName string, decode string, opts generateOptions) {
+	guard := strictMarshalGuard(typeName, "return 0, nil, %s", opts)
+	if opts.bsonMode == "int" {
+		g.Printf(bsonIntMethods, typeName, invalidNumberStatement(typeName, "fmt.Sprint(n)", opts), guard)
+		return
+	}
+	g.Printf(bsonMethods, typeName, decode, guard)
+}
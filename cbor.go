@@ -0,0 +1,58 @@
+package main
+
+// Arguments to format are: [1]: type name [2]: name of the function decoding strings [3]: strict marshaling guard
+const cborMethods = `
+// MarshalCBOR implements the cbor.Marshaler interface for %[1]s
+func (i %[1]s) MarshalCBOR() ([]byte, error) {
+%[3]s	return cbor.Marshal(i.String())
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface for %[1]s
+func (i *%[1]s) UnmarshalCBOR(data []byte) error {
+	var s string
+	if err := cbor.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("%[1]s should be a string: %%w", err)
+	}
+
+	var err error
+	*i, err = %[2]s(s)
+	return err
+}
+`
+
+// Arguments to format are: [1]: type name [2]: integer type [3]: statement handling numbers that are not values
+// of the type [4]: strict marshaling guard
+const cborIntMethods = `
+// MarshalCBOR implements the cbor.Marshaler interface for %[1]s
+func (i %[1]s) MarshalCBOR() ([]byte, error) {
+%[4]s	return cbor.Marshal(%[2]s(i))
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface for %[1]s
+func (i *%[1]s) UnmarshalCBOR(data []byte) error {
+	var n %[2]s
+	if err := cbor.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("%[1]s should be an integer: %%w", err)
+	}
+
+	val := %[1]s(n)
+	if %[2]s(val) != n || !val.IsA%[1]s() {
+		%[3]s
+	}
+	*i = val
+	return nil
+}
+`
+
+func (g *Generator) buildCBORMethods(typeName string, decode string, signed bool, opts generateOptions) {
+	guard := strictMarshalGuard(typeName, "return nil, %s", opts)
+	if opts.cborMode != "int" {
+		g.Printf(cborMethods, typeName, decode, guard)
+		return
+	}
+	intType := "uint64"
+	if signed {
+		intType = "int64"
+	}
+	g.Printf(cborIntMethods, typeName, intType, invalidNumberStatement(typeName, "fmt.Sprint(n)", opts), guard)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Arguments to format are: [1]: type name [2]: descriptions of the values [3]: condition on name matching toComplete
+const completionFunc = `
+var _%[1]sDescriptions = []string{%[2]s}
+
+// %[1]sCompletionFunc completes the names of the %[1]s values, with their
+// descriptions. It can be registered for a flag with RegisterFlagCompletionFunc
+// of cobra.Command.
+func %[1]sCompletionFunc(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var completions []string
+	for i, name := range _%[1]sNames {
+		if !%[3]s {
+			continue
+		}
+		if _%[1]sDescriptions[i] != "" {
+			name += "\t" + _%[1]sDescriptions[i]
+		}
+		completions = append(completions, name)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+`
+
+// Arguments to format are: [1]: type name
+const pflagShortTypeMethods = `
+// Type returns the name of the type, shown in usage messages. %[1]sUsage adds
+// the allowed values to the usage of a flag.
+func (%[1]s) Type() string {
+	return %[1]q
+}
+
+// %[1]sUsage returns the usage of a flag of type %[1]s followed by the names
+// it accepts.
+func %[1]sUsage(usage string) string {
+	return usage + " (" + strings.Join(_%[1]sNames, "|") + ")"
+}
+`
+
+// completionDescription returns the description of a value shown by shell
+// completion, the first paragraph of its doc comment on a single line.
+func completionDescription(doc string) string {
+	description, _, _ := splitDeprecation(doc)
+	paragraph, _, _ := strings.Cut(description, "\n\n")
+	return strings.Join(strings.Fields(paragraph), " ")
+}
+
+func (g *Generator) buildCompletionFunc(runs [][]Value, typeName string, caseSensitive bool) {
+	var descriptions []string
+	for _, values := range runs {
+		for _, value := range values {
+			descriptions = append(descriptions, fmt.Sprintf("%q", completionDescription(value.doc)))
+		}
+	}
+	match := "strings.HasPrefix(strings.ToLower(name), strings.ToLower(toComplete))"
+	if caseSensitive {
+		match = "strings.HasPrefix(name, toComplete)"
+	}
+	g.Printf(completionFunc, typeName, strings.Join(descriptions, ", "), match)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// directivePrefix starts the comments that give enumer instructions about a constant.
+const directivePrefix = "//enumer:"
+
+// directives holds the instructions given by the //enumer: comments of a constant.
+type directives struct {
+	canonical bool     // //enumer:canonical, the constant is printed rather than its aliases.
+	name      string   // //enumer:name "name", the name printed instead of the transformed constant name.
+	aliases   []string // //enumer:alias "a","b", other names accepted when parsing.
+	isDefault bool     // //enumer:default, the constant unknown names are decoded to.
+}
+
+// apply returns v with the directives applied. Names given by directives are
+// used as they are, without transformation.
+func (dirs directives) apply(v Value) Value {
+	v.canonical = dirs.canonical
+	v.isDefault = dirs.isDefault
+	if dirs.name != "" {
+		v.name = dirs.name
+		v.explicit = true
+	}
+	for _, alias := range dirs.aliases {
+		a := v
+		a.name = alias
+		a.explicit = true
+		a.canonical = false
+		a.isDefault = false
+		a.aliases = nil
+		v.aliases = append(v.aliases, a)
+	}
+	return v
+}
+
+// isDirective reports whether the comment is an enumer directive.
+func isDirective(c *ast.Comment) bool {
+	return strings.HasPrefix(c.Text, directivePrefix)
+}
+
+// parseDirectives collects the directives found in the comment groups, any of which may be nil.
+func parseDirectives(groups ...*ast.CommentGroup) directives {
+	var dirs directives
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+		for _, c := range group.List {
+			if !isDirective(c) {
+				continue
+			}
+			directive, args, _ := strings.Cut(strings.TrimPrefix(c.Text, directivePrefix), " ")
+			strs, err := parseDirectiveStrings(args)
+			if err != nil {
+				log.Fatalf("invalid directive %s: %s", c.Text, err)
+			}
+			switch {
+			case directive == "canonical" && len(strs) == 0:
+				dirs.canonical = true
+			case directive == "name" && len(strs) == 1:
+				dirs.name = strs[0]
+			case directive == "alias" && len(strs) > 0:
+				dirs.aliases = append(dirs.aliases, strs...)
+			case directive == "default" && len(strs) == 0:
+				dirs.isDefault = true
+			default:
+				log.Fatalf("unknown directive %s", c.Text)
+			}
+		}
+	}
+	return dirs
+}
+
+// parseDirectiveStrings parses the comma separated list of quoted strings of a directive.
+func parseDirectiveStrings(args string) ([]string, error) {
+	var strs []string
+	args = strings.TrimSpace(args)
+	for args != "" {
+		quoted, err := strconv.QuotedPrefix(args)
+		if err != nil {
+			return nil, fmt.Errorf("expected a quoted string at %q", args)
+		}
+		str, _ := strconv.Unquote(quoted)
+		strs = append(strs, str)
+		args = strings.TrimSpace(args[len(quoted):])
+		if args == "" {
+			break
+		}
+		if args[0] != ',' {
+			return nil, fmt.Errorf("expected a comma at %q", args)
+		}
+		args = strings.TrimSpace(args[1:])
+		if args == "" {
+			return nil, fmt.Errorf("expected a quoted string after the last comma")
+		}
+	}
+	return strs, nil
+}
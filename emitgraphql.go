@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// graphqlName matches the names GraphQL allows for enum values.
+var graphqlName = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// splitDeprecation splits a doc comment into its description and the reason
+// given by its "Deprecated:" paragraph, if it has one.
+func splitDeprecation(doc string) (description, reason string, deprecated bool) {
+	var paragraphs []string
+	for _, p := range strings.Split(doc, "\n\n") {
+		if rest, ok := strings.CutPrefix(p, "Deprecated:"); ok {
+			reason = strings.Join(strings.Fields(rest), " ")
+			deprecated = true
+			continue
+		}
+		paragraphs = append(paragraphs, p)
+	}
+	return strings.Join(paragraphs, "\n\n"), reason, deprecated
+}
+
+// graphqlDescription returns the description as a GraphQL string indented by
+// indent, a block string if it has several lines, or "" if it is empty.
+func graphqlDescription(description, indent string) string {
+	if description == "" {
+		return ""
+	}
+	if !strings.ContainsAny(description, "\n\"\\") {
+		return fmt.Sprintf("%s\"%s\"\n", indent, description)
+	}
+	lines := strings.Split(strings.ReplaceAll(description, `"""`, `\"""`), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indent + line
+		}
+	}
+	return fmt.Sprintf("%s\"\"\"\n%s\n%s\"\"\"\n", indent, strings.Join(lines, "\n"), indent)
+}
+
+// graphqlHeader returns the header of the generated Go file as a comment of
+// GraphQL or YAML.
+func graphqlHeader(header string) string {
+	return "# " + strings.TrimPrefix(header, "// ")
+}
+
+// buildGraphQLFile returns the GraphQL schema declaring the enums generated
+// so far, with the names MarshalGQL writes. Their descriptions are the doc
+// comments, and the values whose doc comment has a "Deprecated:" paragraph
+// are marked with @deprecated.
+func (g *Generator) buildGraphQLFile(header string) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s\n", graphqlHeader(header))
+	for _, e := range g.enums {
+		description, _, _ := splitDeprecation(e.doc)
+		fmt.Fprintf(&buf, "\n%senum %s {\n", graphqlDescription(description, ""), e.typeName)
+		for _, v := range e.values {
+			if !graphqlName.MatchString(v.name) || v.name == "true" || v.name == "false" || v.name == "null" {
+				log.Fatalf("%q of type %s is not a GraphQL enum value, use a -transform such as snake-upper", v.name, e.typeName)
+			}
+			description, reason, deprecated := splitDeprecation(v.doc)
+			fmt.Fprintf(&buf, "%s  %s", graphqlDescription(description, "  "), v.name)
+			switch {
+			case deprecated && reason != "":
+				fmt.Fprintf(&buf, " @deprecated(reason: %s)", jsonQuote(reason))
+			case deprecated:
+				buf.WriteString(" @deprecated")
+			}
+			buf.WriteString("\n")
+		}
+		buf.WriteString("}\n")
+	}
+	return buf.Bytes()
+}
+
+// buildGQLGenModels returns the models section of a gqlgen.yml binding the
+// GraphQL enums to the Go types.
+func (g *Generator) buildGQLGenModels(header string) []byte {
+	path := g.pkg.path
+	if path == "command-line-arguments" {
+		path = importPath(g.pkg.dir)
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s\nmodels:\n", graphqlHeader(header))
+	for _, e := range g.enums {
+		fmt.Fprintf(&buf, "  %s:\n    model:\n      - %s.%s\n", e.typeName, path, e.typeName)
+	}
+	return buf.Bytes()
+}
+
+// importPath returns the import path of the package in dir. Packages given as
+// a list of files are loaded as "command-line-arguments", which gqlgen can't
+// import.
+func importPath(dir string) string {
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.NeedName, Dir: dir}, ".")
+	if err != nil || len(pkgs) != 1 || len(pkgs[0].Errors) > 0 || pkgs[0].PkgPath == "command-line-arguments" {
+		log.Fatalf("can't find the import path of the package in %s for -emit-graphql.models", dir)
+	}
+	return pkgs[0].PkgPath
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pascaldekloe/name"
+)
+
+// protoEnumDef is an enum of a .proto file.
+type protoEnumDef struct {
+	values   map[string]int64 // Numbers of the values, by name.
+	ranges   [][2]int64       // Reserved inclusive ranges of numbers.
+	reserved []string         // Reserved names.
+}
+
+var (
+	protoEnumStartLine = regexp.MustCompile(`^\s*enum\s+(\w+)\s*\{`)
+	protoEnumValueLine = regexp.MustCompile(`^\s*(\w+)\s*=\s*(-?\d+)\s*[;\[]`)
+	protoReservedLine  = regexp.MustCompile(`^\s*reserved\s+(.*);`)
+)
+
+// readProtoEnums reads the enums of the .proto file at path, emitted by a
+// previous run, or returns nil if there's no such file.
+func readProtoEnums(path string) map[string]*protoEnumDef {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		log.Fatalf("reading %s: %s", path, err)
+	}
+	enums, err := parseProtoEnums(data)
+	if err != nil {
+		log.Fatalf("parsing %s: %s", path, err)
+	}
+	return enums
+}
+
+// parseProtoEnums parses the values and reserved statements of the enums of
+// a .proto file, by enum name. Only the subset of the syntax written by
+// buildProtoFile is understood.
+func parseProtoEnums(data []byte) (map[string]*protoEnumDef, error) {
+	enums := make(map[string]*protoEnumDef)
+	var def *protoEnumDef
+	for _, line := range strings.Split(string(data), "\n") {
+		if m := protoEnumStartLine.FindStringSubmatch(line); m != nil {
+			def = &protoEnumDef{values: make(map[string]int64)}
+			enums[m[1]] = def
+			continue
+		}
+		if def == nil {
+			continue
+		}
+		if strings.TrimSpace(line) == "}" {
+			def = nil
+		} else if m := protoEnumValueLine.FindStringSubmatch(line); m != nil {
+			n, err := strconv.ParseInt(m[2], 10, 32)
+			if err != nil {
+				return nil, err
+			}
+			def.values[m[1]] = n
+		} else if m := protoReservedLine.FindStringSubmatch(line); m != nil {
+			for _, item := range strings.Split(m[1], ",") {
+				item = strings.TrimSpace(item)
+				if s, err := strconv.Unquote(item); err == nil {
+					def.reserved = append(def.reserved, s)
+					continue
+				}
+				r, err := parseProtoRange(item)
+				if err != nil {
+					return nil, err
+				}
+				def.ranges = append(def.ranges, r)
+			}
+		}
+	}
+	return enums, nil
+}
+
+// parseProtoRange parses a reserved number, or range of numbers such as "5 to 7".
+func parseProtoRange(s string) ([2]int64, error) {
+	lo, hi, isRange := strings.Cut(s, " to ")
+	if !isRange {
+		hi = lo
+	}
+	var r [2]int64
+	for i, bound := range []string{lo, hi} {
+		bound = strings.TrimSpace(bound)
+		if bound == "max" {
+			r[i] = math.MaxInt32
+			continue
+		}
+		n, err := strconv.ParseInt(bound, 10, 32)
+		if err != nil {
+			return r, fmt.Errorf("invalid reserved number %q", s)
+		}
+		r[i] = n
+	}
+	return r, nil
+}
+
+// formatProtoRanges merges the ranges of numbers and formats them for a
+// reserved statement.
+func formatProtoRanges(ranges [][2]int64) string {
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+	var merged [][2]int64
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r[0] <= merged[n-1][1]+1 {
+			if r[1] > merged[n-1][1] {
+				merged[n-1][1] = r[1]
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	strs := make([]string, len(merged))
+	for i, r := range merged {
+		switch {
+		case r[0] == r[1]:
+			strs[i] = strconv.FormatInt(r[0], 10)
+		case r[1] == math.MaxInt32:
+			strs[i] = fmt.Sprintf("%d to max", r[0])
+		default:
+			strs[i] = fmt.Sprintf("%d to %d", r[0], r[1])
+		}
+	}
+	return strings.Join(strs, ", ")
+}
+
+// protoValueName returns the SCREAMING_SNAKE name of a proto value, prefixed
+// with the one of its type as buf style wants it.
+func protoValueName(prefix, s string) string {
+	s = strings.ToUpper(name.Delimit(s, '_'))
+	if strings.HasPrefix(s, prefix+"_") {
+		return s
+	}
+	return prefix + "_" + s
+}
+
+// buildProtoFile returns the .proto file defining the enums generated so far,
+// and the other enums of the previous file as they were, sorted by name. The
+// numbers and names the previous enums of the file used and the new ones
+// don't are reserved, as well as those they already reserved.
+func (g *Generator) buildProtoFile(header, protoPackage string, previous map[string]*protoEnumDef) []byte {
+	defs := make(map[string]*protoEnumDef, len(previous)+len(g.enums))
+	for typeName, prev := range previous {
+		defs[typeName] = prev
+	}
+	for _, e := range g.enums {
+		def := protoEnumDefOf(e)
+		if prev := previous[e.typeName]; prev != nil {
+			if err := def.reserveDropped(prev, e.typeName); err != nil {
+				log.Fatal(err)
+			}
+		}
+		defs[e.typeName] = def
+	}
+	typeNames := make([]string, 0, len(defs))
+	for typeName := range defs {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s\n\nsyntax = \"proto3\";\n\npackage %s;\n", header, protoPackage)
+	for _, typeName := range typeNames {
+		def := defs[typeName]
+		fmt.Fprintf(&buf, "\nenum %s {\n", typeName)
+		if len(def.ranges) > 0 {
+			fmt.Fprintf(&buf, "  reserved %s;\n", formatProtoRanges(def.ranges))
+		}
+		if len(def.reserved) > 0 {
+			names := make([]string, len(def.reserved))
+			for i, n := range def.reserved {
+				names[i] = strconv.Quote(n)
+			}
+			fmt.Fprintf(&buf, "  reserved %s;\n", strings.Join(names, ", "))
+		}
+		if len(def.ranges) > 0 || len(def.reserved) > 0 {
+			buf.WriteString("\n")
+		}
+		// proto3 wants the zero value first.
+		names := make([]string, 0, len(def.values))
+		for n := range def.values {
+			names = append(names, n)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			a, b := def.values[names[i]], def.values[names[j]]
+			return (a == 0 && b != 0) || (b != 0 && a < b)
+		})
+		for _, n := range names {
+			fmt.Fprintf(&buf, "  %s = %d;\n", n, def.values[n])
+		}
+		buf.WriteString("}\n")
+	}
+	return buf.Bytes()
+}
+
+// protoEnumDefOf returns the proto enum of the generated enum, with an
+// <TYPE>_UNSPECIFIED zero value if it has none.
+func protoEnumDefOf(e enum) *protoEnumDef {
+	prefix := strings.ToUpper(name.Delimit(e.typeName, '_'))
+	def := &protoEnumDef{values: make(map[string]int64)}
+	hasZero := false
+	for _, v := range e.values {
+		if v.isString {
+			log.Fatalf("type %s is a string, it can't be emitted as a proto enum", e.typeName)
+		}
+		n := int64(v.value)
+		if (v.signed && (n < math.MinInt32 || n > math.MaxInt32)) || (!v.signed && v.value > math.MaxInt32) {
+			log.Fatalf("%s of type %s is out of the range of proto enums", v.originalName, e.typeName)
+		}
+		hasZero = hasZero || n == 0
+		name := protoValueName(prefix, v.name)
+		if _, ok := def.values[name]; ok {
+			log.Fatalf("two values of type %s have the proto name %s", e.typeName, name)
+		}
+		def.values[name] = n
+	}
+	if !hasZero {
+		def.values[prefix+"_UNSPECIFIED"] = 0
+	}
+	return def
+}
+
+// reserveDropped reserves the numbers and names prev used and def doesn't,
+// and those prev reserved. Using them again, or giving a name of prev another
+// number, is an error as it changes the meaning of the encoded values.
+func (def *protoEnumDef) reserveDropped(prev *protoEnumDef, typeName string) error {
+	numbers := make(map[int64]bool)
+	for n, number := range def.values {
+		numbers[number] = true
+		if prevNumber, ok := prev.values[n]; ok && prevNumber != number {
+			return fmt.Errorf("%s of type %s was %d and can't become %d", n, typeName, prevNumber, number)
+		}
+		for _, r := range prev.ranges {
+			if number >= r[0] && number <= r[1] {
+				return fmt.Errorf("%s of type %s uses the reserved number %d", n, typeName, number)
+			}
+		}
+		for _, reserved := range prev.reserved {
+			if n == reserved {
+				return fmt.Errorf("%s of type %s uses a reserved name", n, typeName)
+			}
+		}
+	}
+	def.ranges = append(def.ranges, prev.ranges...)
+	def.reserved = append(def.reserved, prev.reserved...)
+	for n, number := range prev.values {
+		if !numbers[number] {
+			def.ranges = append(def.ranges, [2]int64{number, number})
+		}
+		if _, ok := def.values[n]; !ok {
+			def.reserved = append(def.reserved, n)
+		}
+	}
+	sort.Strings(def.reserved)
+	return nil
+}
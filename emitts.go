@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// maxSafeInteger is the largest integer TypeScript numbers hold exactly.
+const maxSafeInteger = 1<<53 - 1
+
+// jsonQuote returns s as a JSON string, which is also a string literal of
+// TypeScript and GraphQL.
+func jsonQuote(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// tsDoc returns the doc comment as a JSDoc comment indented by indent, or ""
+// if there's none.
+func tsDoc(doc, indent string) string {
+	if doc == "" {
+		return ""
+	}
+	doc = strings.ReplaceAll(doc, "*/", "*\\/")
+	if !strings.Contains(doc, "\n") {
+		return indent + "/** " + doc + " */\n"
+	}
+	lines := strings.Split(doc, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(indent+" * "+line, " ")
+	}
+	return indent + "/**\n" + strings.Join(lines, "\n") + "\n" + indent + " */\n"
+}
+
+// buildTSFile returns the TypeScript module declaring the enums generated so
+// far with the values MarshalJSON produces: a union of the names of
+// _<Type>Names or, for -json.output=number, a numeric enum. Each one comes with
+// a <Type>Values array, and a <Type>Schema for Zod if zod is set.
+func (g *Generator) buildTSFile(header string, zod bool, opts generateOptions) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s\n", header)
+	if zod {
+		buf.WriteString("\nimport { z } from \"zod\";\n")
+	}
+	for _, e := range g.enums {
+		buf.WriteString("\n")
+		if jsonWritesNumbers(opts) {
+			members := make([]string, len(e.values))
+			fmt.Fprintf(&buf, "export enum %s {\n", e.typeName)
+			for i, v := range e.values {
+				if (v.signed && (int64(v.value) > maxSafeInteger || int64(v.value) < -maxSafeInteger)) || (!v.signed && v.value > maxSafeInteger) {
+					log.Fatalf("%s of type %s can't be held exactly by a TypeScript number", v.originalName, e.typeName)
+				}
+				fmt.Fprintf(&buf, "%s  %s = %s,\n", tsDoc(v.doc, "  "), v.originalName, v.str)
+				members[i] = e.typeName + "." + v.originalName
+			}
+			buf.WriteString("}\n")
+			fmt.Fprintf(&buf, "\nexport const %sValues = [%s] as const;\n", e.typeName, strings.Join(members, ", "))
+			if zod {
+				fmt.Fprintf(&buf, "\nexport const %sSchema = z.nativeEnum(%s);\n", e.typeName, e.typeName)
+			}
+			continue
+		}
+
+		names := make([]string, len(e.values))
+		for i, v := range e.values {
+			names[i] = jsonQuote(v.name)
+		}
+		fmt.Fprintf(&buf, "export type %s = %s;\n", e.typeName, strings.Join(names, " | "))
+		fmt.Fprintf(&buf, "\nexport const %sValues = [%s] as const;\n", e.typeName, strings.Join(names, ", "))
+		if zod {
+			fmt.Fprintf(&buf, "\nexport const %sSchema = z.enum(%sValues);\n", e.typeName, e.typeName)
+		}
+	}
+	return buf.Bytes()
+}
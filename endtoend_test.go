@@ -76,7 +76,8 @@ func TestEndToEnd(t *testing.T) {
 		// Names are known to be ASCII and long enough.
 		var typeName string
 		var transformNameMethod string
-		var useTypedErrors bool
+		var extraArgs []string
+		var modules []string // Modules the program imports, run in a module of its own.
 
 		switch name {
 		case "transform_snake.go":
@@ -115,19 +116,155 @@ func TestEndToEnd(t *testing.T) {
 		case "typedErrors.go":
 			typeName = "TypedErrorsValue"
 			transformNameMethod = "noop"
-			useTypedErrors = true
+			extraArgs = []string{"-typederrors", "-values"}
+		case "bitflag.go":
+			typeName = "Perm"
+			transformNameMethod = "noop"
+			extraArgs = []string{"-bitflag", "-json"}
+		case "pill.go":
+			typeName = "Pill"
+			transformNameMethod = "noop"
+			extraArgs = []string{"-aliases", "-json"}
+		case "status.go":
+			typeName = "Status"
+			transformNameMethod = "noop"
+			extraArgs = []string{"-casesensitive", "-json"}
+		case "color.go":
+			typeName = "Color"
+			transformNameMethod = "noop"
+			extraArgs = []string{"-json", "-sql"}
+		case "task.go":
+			typeName = "Task"
+			transformNameMethod = "snake"
+			extraArgs = []string{"-trimprefix", "Task", "-json"}
+		case "level.go":
+			typeName = "Level"
+			transformNameMethod = "noop"
+			extraArgs = []string{"-default", "LevelUnknown", "-json", "-text", "-sql"}
+		case "region.go":
+			typeName = "Region"
+			transformNameMethod = "noop"
+			extraArgs = []string{"-json", "-json.numbers"}
+		case "shape.go":
+			typeName = "Shape"
+			transformNameMethod = "noop"
+			extraArgs = []string{"-strictmarshal", "-typederrors", "-json", "-text", "-sql"}
+		case "priority.go":
+			typeName = "Priority"
+			transformNameMethod = "noop"
+			extraArgs = []string{"-sql=int"}
+		case "tilt.go":
+			typeName = "Tilt"
+			transformNameMethod = "noop"
+			extraArgs = []string{"-binary"}
+		case "fruit.go":
+			typeName = "Fruit"
+			transformNameMethod = "noop"
+			extraArgs = []string{"-xml"}
+		case "tier.go":
+			typeName = "Tier"
+			transformNameMethod = "noop"
+			extraArgs = []string{"-env", "-casesensitive"}
+		case "mood.go":
+			typeName = "Mood"
+			transformNameMethod = "noop"
+			extraArgs = []string{"-slog", "-formatter"}
+		case "size.go":
+			typeName = "Size"
+			transformNameMethod = "noop"
+			extraArgs = []string{"-nullable", "-json", "-text", "-sql", "-slog", "-formatter"}
+		case "suit.go":
+			typeName = "Suit"
+			transformNameMethod = "noop"
+			extraArgs = []string{"-pgx", "-typederrors"}
+			modules = []string{"github.com/jackc/pgx/v5@v5.11.0"}
+		case "weight.go":
+			typeName = "Weight"
+			transformNameMethod = "noop"
+			extraArgs = []string{"-nullable", "-yaml=v3"}
+			modules = []string{"gopkg.in/yaml.v3@v3.0.1"}
+		case "medal.go":
+			typeName = "Medal"
+			transformNameMethod = "noop"
+			extraArgs = []string{"-bson"}
+			modules = []string{"go.mongodb.org/mongo-driver/v2@v2.9.1"}
+		case "grade.go":
+			typeName = "Grade"
+			transformNameMethod = "noop"
+			extraArgs = []string{"-bson=int", "-typederrors"}
+			modules = []string{"go.mongodb.org/mongo-driver/v2@v2.9.1"}
+		case "urgency.go":
+			typeName = "Urgency"
+			transformNameMethod = "noop"
+			extraArgs = []string{"-gqlgen=context", "-strictmarshal", "-typederrors", "-json"}
+			modules = []string{"github.com/99designs/gqlgen@v0.17.78"}
+		case "league.go":
+			typeName = "League"
+			transformNameMethod = "noop"
+			extraArgs = []string{"-msgpack", "-cbor", "-strictmarshal", "-typederrors"}
+			modules = []string{"github.com/vmihailenco/msgpack/v5@v5.4.1", "github.com/fxamacker/cbor/v2@v2.9.4"}
+		case "rank.go":
+			typeName = "Rank"
+			transformNameMethod = "noop"
+			extraArgs = []string{"-msgpack=int", "-cbor=int", "-typederrors"}
+			modules = []string{"github.com/vmihailenco/msgpack/v5@v5.4.1", "github.com/fxamacker/cbor/v2@v2.9.4"}
+		case "mode.go":
+			typeName = "Mode"
+			transformNameMethod = "noop"
+			extraArgs = []string{"-pflag.value", "-pflag.shorttype", "-pflag.completion"}
+			modules = []string{"github.com/spf13/cobra@v1.10.2"}
 		default:
 			typeName = fmt.Sprintf("%c%s", name[0]+'A'-'a', name[1:len(name)-len(".go")])
 			transformNameMethod = "noop"
 		}
 
-		stringerCompileAndRun(t, dir, stringer, typeName, name, transformNameMethod, useTypedErrors)
+		if modules != nil {
+			stringerCompileAndRunInModule(t, dir, stringer, typeName, name, transformNameMethod, extraArgs, modules)
+			continue
+		}
+		stringerCompileAndRun(t, dir, stringer, typeName, name, transformNameMethod, extraArgs)
+	}
+}
+
+// stringerCompileAndRunInModule is stringerCompileAndRun for a program
+// importing modules enumer doesn't require, such as a driver. The program is
+// run in a module of its own requiring them, which is skipped if they can't
+// be downloaded.
+func stringerCompileAndRunInModule(t *testing.T, dir, stringer, typeName, fileName, transformNameMethod string, extraArgs, modules []string) {
+	t.Logf("run: %s %s in a module\n", fileName, typeName)
+	root, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	modDir := filepath.Join(dir, strings.TrimSuffix(fileName, ".go"))
+	if err := os.Mkdir(modDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	goMod := "module enumertest\n\ngo 1.24\n\nrequire github.com/dmarkham/enumer v0.0.0\n\nreplace github.com/dmarkham/enumer => " + root + "\n"
+	if err := os.WriteFile(filepath.Join(modDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runInDir(modDir, "go", append([]string{"get"}, modules...)...); err != nil {
+		t.Logf("skipping %s, the modules can't be downloaded: %s", fileName, err)
+		return
+	}
+	if err := copy(filepath.Join(modDir, fileName), filepath.Join("testdata", fileName)); err != nil {
+		t.Fatalf("copying file to temporary directory: %s", err)
+	}
+	args := []string{"-type", typeName, "-output", typeName + "_string.go", "-transform", transformNameMethod}
+	args = append(args, extraArgs...)
+	args = append(args, fileName)
+	if err := runInDir(modDir, stringer, args...); err != nil {
+		t.Fatal(err)
+	}
+	if err := runInDir(modDir, "go", "run", "-mod=mod", "."); err != nil {
+		t.Fatal(err)
 	}
 }
 
 // stringerCompileAndRun runs stringer for the named file and compiles and
 // runs the target binary in directory dir. That binary will panic if the String method is incorrect.
-func stringerCompileAndRun(t *testing.T, dir, stringer, typeName, fileName, transformNameMethod string, useTypedErrors bool) {
+func stringerCompileAndRun(t *testing.T, dir, stringer, typeName, fileName, transformNameMethod string, extraArgs []string) {
 	t.Logf("run: %s %s\n", fileName, typeName)
 	source := filepath.Join(dir, fileName)
 	err := copy(source, filepath.Join("testdata", fileName))
@@ -137,9 +274,7 @@ func stringerCompileAndRun(t *testing.T, dir, stringer, typeName, fileName, tran
 	stringSource := filepath.Join(dir, typeName+"_string.go")
 	// Run stringer in temporary directory.
 	args := []string{"-type", typeName, "-output", stringSource, "-transform", transformNameMethod}
-	if useTypedErrors {
-		args = append(args, "-typederrors", "-values")
-	}
+	args = append(args, extraArgs...)
 	args = append(args, source)
 	err = run(stringer, args...)
 	if err != nil {
@@ -1,8 +1,12 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"log"
+	"strings"
+)
 
-// Arguments to format are: [1]: type name [2]: complete error expression
+// Arguments to format are: [1]: type name [2]: complete error expression [3]: zero value of the type
 const stringNameToValueMethod = `// %[1]sString retrieves an enum value from the enum constants string name.
 // Throws an error if the param is not part of the enum.
 func %[1]sString(s string) (%[1]s, error) {
@@ -10,10 +14,34 @@ func %[1]sString(s string) (%[1]s, error) {
 		return val, nil
 	}
 
-	if val, ok := _%[1]sNameToValueMap[strings.ToLower(s)]; ok {
+	if val, ok := _%[1]sLowerNameToValueMap[strings.ToLower(s)]; ok {
 		return val, nil
 	}
-	return 0, %[2]s
+	return %[3]s, %[2]s
+}
+`
+
+// Arguments to format are: [1]: type name [2]: complete error expression [3]: zero value of the type [4]: function name
+const stringNameToValueExactMethod = `// %[4]s retrieves an enum value from the enum constants string name,
+// which must match with the exact case.
+// Throws an error if the param is not part of the enum.
+func %[4]s(s string) (%[1]s, error) {
+	if val, ok := _%[1]sNameToValueMap[s]; ok {
+		return val, nil
+	}
+	return %[3]s, %[2]s
+}
+`
+
+// Arguments to format are: [1]: type name [2]: name of the default constant
+const decodeWithDefaultFunc = `
+// _%[1]sDecode retrieves an enum value from the enum constants string name
+// for the unmarshaling methods, which get %[2]s for unknown names.
+func _%[1]sDecode(s string) (%[1]s, error) {
+	if val, err := %[1]sString(s); err == nil {
+		return val, nil
+	}
+	return %[2]s, nil
 }
 `
 
@@ -53,6 +81,16 @@ func (i %[1]s) IsA%[1]s() bool {
 }
 `
 
+// Arguments to format are: [1]: type name
+const aliasesMethod = `// %[1]sAliases returns the other names %[1]sString accepts for the value,
+// besides the one returned by its String method.
+func %[1]sAliases(v %[1]s) []string {
+	strs := make([]string, len(_%[1]sAliases[v]))
+	copy(strs, _%[1]sAliases[v])
+	return strs
+}
+`
+
 // Arguments to format are: [1]: type name
 const altStringValuesMethod = `func (%[1]s) Values() []string {
 	return %[1]sStrings()
@@ -64,7 +102,7 @@ func (g *Generator) buildAltStringValuesMethod(typeName string) {
 	g.Printf(altStringValuesMethod, typeName)
 }
 
-func (g *Generator) buildBasicExtras(runs [][]Value, typeName string, runsThreshold int, useTypedErrors bool) {
+func (g *Generator) buildBasicExtras(runs [][]Value, typeName string, runsThreshold int, opts generateOptions) {
 	// At this moment, either "g.declareIndexAndNameVars()" or "g.declareNameVars()" has been called
 
 	// Print the slice of values
@@ -77,31 +115,69 @@ func (g *Generator) buildBasicExtras(runs [][]Value, typeName string, runsThresh
 	g.Printf("}\n\n")
 
 	// Print the map between name and value
-	g.printValueMap(runs, typeName, runsThreshold)
+	g.printValueMap(runs, typeName, runsThreshold, opts.caseSensitive)
 
 	// Print the slice of names
 	g.printNamesSlice(runs, typeName, runsThreshold)
 
+	if opts.includeAliases {
+		g.printAliasesMap(runs, typeName)
+	}
+
 	// Print the basic extra methods
-	var errorCode string
-	if useTypedErrors {
-		errorCode = fmt.Sprintf(`errors.Join(enumerrs.ErrValueInvalid, fmt.Errorf("%%s does not belong to %s values", s))`, typeName)
+	errorCode := invalidValueError(typeName, "s", opts.useTypedErrors)
+	if opts.bitflag {
+		g.buildBitflagStringMethods(typeName, errorCode, opts.bitflagSeparator, opts.caseSensitive)
 	} else {
-		errorCode = fmt.Sprintf(`fmt.Errorf("%%s does not belong to %s values", s)`, typeName)
+		g.printStringMethods(typeName, errorCode, "0", opts.caseSensitive)
 	}
-	g.Printf(stringNameToValueMethod, typeName, errorCode)
 	g.Printf(stringValuesMethod, typeName)
 	g.Printf(stringsMethod, typeName)
-	if len(runs) <= runsThreshold {
+	if opts.bitflag {
+		g.Printf(stringBelongsMethodMask, typeName)
+	} else if len(runs) <= runsThreshold {
 		g.Printf(stringBelongsMethodLoop, typeName)
 	} else { // There is a map of values, the code is simpler then
 		g.Printf(stringBelongsMethodSet, typeName)
 	}
 }
 
-func (g *Generator) printValueMap(runs [][]Value, typeName string, runsThreshold int) {
+// invalidValueError returns the expression of the error reporting that the
+// string held by the variable str does not belong to the type values.
+func invalidValueError(typeName, str string, useTypedErrors bool) string {
+	if useTypedErrors {
+		return fmt.Sprintf(`errors.Join(enumerrs.ErrValueInvalid, fmt.Errorf("%%s does not belong to %s values", %s))`, typeName, str)
+	}
+	return fmt.Sprintf(`fmt.Errorf("%%s does not belong to %s values", %s)`, typeName, str)
+}
+
+// printStringMethods prints the <Type>String function, case insensitive unless
+// caseSensitive is set, and the always case sensitive <Type>StringStrict function.
+func (g *Generator) printStringMethods(typeName, errorCode, zero string, caseSensitive bool) {
+	if caseSensitive {
+		g.Printf(stringNameToValueExactMethod, typeName, errorCode, zero, typeName+"String")
+	} else {
+		g.Printf(stringNameToValueMethod, typeName, errorCode, zero)
+	}
+	g.Printf(stringNameToValueExactMethod, typeName, errorCode, zero, typeName+"StringStrict")
+}
+
+// printValueMap prints the map between the names and the values and, unless
+// caseSensitive is set, the map between the lower case names and the values.
+func (g *Generator) printValueMap(runs [][]Value, typeName string, runsThreshold int, caseSensitive bool) {
+	g.printNameToValueMap(runs, typeName, runsThreshold, false)
+	if !caseSensitive {
+		g.printNameToValueMap(runs, typeName, runsThreshold, true)
+	}
+}
+
+func (g *Generator) printNameToValueMap(runs [][]Value, typeName string, runsThreshold int, lower bool) {
 	thereAreRuns := len(runs) > 1 && len(runs) <= runsThreshold
-	g.Printf("\nvar _%sNameToValueMap = map[string]%s{\n", typeName, typeName)
+	kind := ""
+	if lower {
+		kind = "Lower"
+	}
+	g.Printf("\nvar _%s%sNameToValueMap = map[string]%s{\n", typeName, kind, typeName)
 
 	var n int
 	var runID string
@@ -114,13 +190,67 @@ func (g *Generator) printValueMap(runs [][]Value, typeName string, runsThreshold
 		}
 
 		for _, value := range values {
-			g.Printf("\t_%sName%s[%d:%d]: %s,\n", typeName, runID, n, n+len(value.name), value.originalName)
-			g.Printf("\t_%sLowerName%s[%d:%d]: %s,\n", typeName, runID, n, n+len(value.name), value.originalName)
+			g.Printf("\t_%s%sName%s[%d:%d]: %s,\n", typeName, kind, runID, n, n+len(value.name), value.originalName)
 			n += len(value.name)
 		}
 	}
+	g.printAliasEntries(runs, lower)
+	g.Printf("}\n\n")
+}
+
+// printAliasEntries prints the name to value map entries of the aliases, in
+// lower case if lower is set. Alias names that are already in the map are
+// skipped; an alias can't have the exact name of another value though.
+func (g *Generator) printAliasEntries(runs [][]Value, lower bool) {
+	caseOf := func(s string) string {
+		if lower {
+			return strings.ToLower(s)
+		}
+		return s
+	}
+	names := make(map[string]string)
+	for _, values := range runs {
+		for _, value := range values {
+			names[caseOf(value.name)] = value.originalName
+		}
+	}
+	for _, values := range runs {
+		for _, value := range values {
+			for _, alias := range value.aliases {
+				name := caseOf(alias.name)
+				if other, ok := names[name]; ok {
+					if !lower && other != value.originalName {
+						log.Fatalf("alias %q of %s is already the name of %s", alias.name, value.originalName, other)
+					}
+					continue
+				}
+				names[name] = value.originalName
+				g.Printf("\t%q: %s,\n", name, alias.originalName)
+			}
+		}
+	}
+}
+
+// printAliasesMap prints the map from values to their alias names.
+func (g *Generator) printAliasesMap(runs [][]Value, typeName string) {
+	g.Printf("\nvar _%sAliases = map[%s][]string{\n", typeName, typeName)
+	for _, values := range runs {
+		for _, value := range values {
+			var aliases []string
+			for _, alias := range value.aliases {
+				if alias.name != value.name {
+					aliases = append(aliases, fmt.Sprintf("%q", alias.name))
+				}
+			}
+			if len(aliases) > 0 {
+				g.Printf("\t%s: {%s},\n", value.originalName, strings.Join(aliases, ", "))
+			}
+		}
+	}
 	g.Printf("}\n\n")
+	g.Printf(aliasesMethod, typeName)
 }
+
 func (g *Generator) printNamesSlice(runs [][]Value, typeName string, runsThreshold int) {
 	thereAreRuns := len(runs) > 1 && len(runs) <= runsThreshold
 	g.Printf("\nvar _%sNames = []string{\n", typeName)
@@ -143,13 +273,24 @@ func (g *Generator) printNamesSlice(runs [][]Value, typeName string, runsThresho
 	g.Printf("}\n\n")
 }
 
-// Arguments to format are: [1]: type name
-const jsonMethods = `
+// Arguments to format are: [1]: type name [2]: strict marshaling guard
+const jsonMarshalMethod = `
 // MarshalJSON implements the json.Marshaler interface for %[1]s
 func (i %[1]s) MarshalJSON() ([]byte, error) {
-	return json.Marshal(i.String())
+%[2]s	return json.Marshal(i.String())
 }
+`
 
+// Arguments to format are: [1]: type name [2]: underlying type [3]: strict marshaling guard
+const jsonMarshalNumberMethod = `
+// MarshalJSON implements the json.Marshaler interface for %[1]s
+func (i %[1]s) MarshalJSON() ([]byte, error) {
+%[3]s	return json.Marshal(%[2]s(i))
+}
+`
+
+// Arguments to format are: [1]: type name [2]: name of the function decoding strings
+const jsonUnmarshalMethod = `
 // UnmarshalJSON implements the json.Unmarshaler interface for %[1]s
 func (i *%[1]s) UnmarshalJSON(data []byte) error {
 	var s string
@@ -158,43 +299,124 @@ func (i *%[1]s) UnmarshalJSON(data []byte) error {
 	}
 
 	var err error
-	*i, err = %[1]sString(s)
+	*i, err = %[2]s(s)
 	return err
 }
 `
 
-func (g *Generator) buildJSONMethods(runs [][]Value, typeName string, runsThreshold int, useTypedErrors bool) {
-	// For now, just use the standard template
-	// We rely on the %[1]sString method to provide typed errors when enabled
-	g.Printf(jsonMethods, typeName)
+// Arguments to format are: [1]: type name [2]: name of the function decoding strings
+// [3]: underlying type [4]: statement handling numbers that are not values of the type
+const jsonUnmarshalLenientMethod = `
+// UnmarshalJSON implements the json.Unmarshaler interface for %[1]s,
+// accepting either the name or the number of a value.
+func (i *%[1]s) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		var err error
+		*i, err = %[2]s(s)
+		return err
+	}
+
+	var n %[3]s
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("%[1]s should be a string or a number, got %%s", data)
+	}
+	if !%[1]s(n).IsA%[1]s() {
+		%[4]s
+	}
+	*i = %[1]s(n)
+	return nil
 }
+`
 
-// Arguments to format are: [1]: type name
+// jsonWritesNumbers reports whether the enums are marshaled to JSON as numbers
+// rather than names.
+func jsonWritesNumbers(opts generateOptions) bool {
+	return opts.includeJSON && opts.jsonOutput == "number"
+}
+
+// checkJSONDescribed fails if the JSON form of the enums can't be described
+// by listing their values, as the named flags do.
+func checkJSONDescribed(flags string, opts generateOptions) {
+	if !opts.includeJSON && !opts.includeText {
+		log.Fatalf("%s describe the JSON marshaling of -json or -text, one of them must be set", flags)
+	}
+	if opts.bitflag {
+		log.Fatalf("%s can't be combined with -bitflag, the combined flags are not an enum", flags)
+	}
+}
+
+func (g *Generator) buildJSONMethods(runs [][]Value, typeName string, decode string, opts generateOptions) {
+	guard := strictMarshalGuard(typeName, "return nil, %s", opts)
+	if opts.jsonOutput == "number" {
+		g.Printf(jsonMarshalNumberMethod, typeName, g.underlyingTypeName(typeName), guard)
+	} else {
+		g.Printf(jsonMarshalMethod, typeName, guard)
+	}
+	if !opts.jsonNumbers && opts.jsonOutput != "number" {
+		// We rely on the %[1]sString method to provide typed errors when enabled
+		g.Printf(jsonUnmarshalMethod, typeName, decode)
+		return
+	}
+	g.Printf(jsonUnmarshalLenientMethod, typeName, decode, g.underlyingTypeName(typeName), invalidNumberStatement(typeName, "data", opts))
+}
+
+// invalidNumberStatement returns the statement the decoding methods run on
+// numbers that are not values of the type, formatted by str: numbers are
+// handled like unknown names, an error unless there's a default value.
+func invalidNumberStatement(typeName, str string, opts generateOptions) string {
+	if opts.defaultValue != "" {
+		return fmt.Sprintf("*i = %s\n\t\treturn nil", opts.defaultValue)
+	}
+	return "return " + invalidValueError(typeName, str, opts.useTypedErrors)
+}
+
+// strictMarshalGuard returns the statement a marshaling method starts with to
+// fail when the value is not part of the enum, or "" unless -strictmarshal is
+// set. The format fail is given the error expression and returns from the method.
+func strictMarshalGuard(typeName, fail string, opts generateOptions) string {
+	if !opts.strictMarshal {
+		return ""
+	}
+	return fmt.Sprintf("\tif !i.IsA%s() {\n\t\t%s\n\t}\n", typeName,
+		fmt.Sprintf(fail, invalidValueError(typeName, "i", opts.useTypedErrors)))
+}
+
+// underlyingTypeName returns the name of the basic type the enum type is defined from.
+func (g *Generator) underlyingTypeName(typeName string) string {
+	obj := g.pkg.typesPkg.Scope().Lookup(typeName)
+	if obj == nil {
+		log.Fatalf("type %s not found in package %s", typeName, g.pkg.name)
+	}
+	return obj.Type().Underlying().String()
+}
+
+// Arguments to format are: [1]: type name [2]: name of the function decoding strings [3]: strict marshaling guard
 const textMethods = `
 // MarshalText implements the encoding.TextMarshaler interface for %[1]s
 func (i %[1]s) MarshalText() ([]byte, error) {
-	return []byte(i.String()), nil
+%[3]s	return []byte(i.String()), nil
 }
 
 // UnmarshalText implements the encoding.TextUnmarshaler interface for %[1]s
 func (i *%[1]s) UnmarshalText(text []byte) error {
 	var err error
-	*i, err = %[1]sString(string(text))
+	*i, err = %[2]s(string(text))
 	return err
 }
 `
 
-func (g *Generator) buildTextMethods(runs [][]Value, typeName string, runsThreshold int, useTypedErrors bool) {
+func (g *Generator) buildTextMethods(runs [][]Value, typeName string, decode string, guard string) {
 	// For now, just use the standard template
 	// We rely on the %[1]sString method to provide typed errors when enabled
-	g.Printf(textMethods, typeName)
+	g.Printf(textMethods, typeName, decode, guard)
 }
 
-// Arguments to format are: [1]: type name
+// Arguments to format are: [1]: type name [2]: name of the function decoding strings [3]: strict marshaling guard
 const yamlMethods = `
 // MarshalYAML implements a YAML Marshaler for %[1]s
 func (i %[1]s) MarshalYAML() (interface{}, error) {
-	return i.String(), nil
+%[3]s	return i.String(), nil
 }
 
 // UnmarshalYAML implements a YAML Unmarshaler for %[1]s
@@ -205,15 +427,51 @@ func (i *%[1]s) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	}
 
 	var err error
-	*i, err = %[1]sString(s)
+	*i, err = %[2]s(s)
 	return err
 }
 `
 
-func (g *Generator) buildYAMLMethods(runs [][]Value, typeName string, runsThreshold int, useTypedErrors bool) {
+func (g *Generator) buildYAMLMethods(runs [][]Value, typeName string, decode string, guard string) {
 	// For now, just use the standard template
 	// We rely on the %[1]sString method to provide typed errors when enabled
-	g.Printf(yamlMethods, typeName)
+	g.Printf(yamlMethods, typeName, decode, guard)
+}
+
+// Arguments to format are: [1]: type name [2]: name of the function decoding strings [3]: strict marshaling guard
+const yamlNodeMethods = `
+// MarshalYAML implements the yaml.Marshaler interface for %[1]s
+func (i %[1]s) MarshalYAML() (interface{}, error) {
+%[3]s	return i.String(), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for %[1]s
+func (i *%[1]s) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind != yaml.ScalarNode {
+		kind := "document"
+		switch node.Kind {
+		case yaml.MappingNode:
+			kind = "mapping"
+		case yaml.SequenceNode:
+			kind = "sequence"
+		}
+		return fmt.Errorf("line %%d, column %%d: %[1]s should be a scalar, got a %%s", node.Line, node.Column, kind)
+	}
+
+	var err error
+	*i, err = %[2]s(node.Value)
+	if err != nil {
+		return fmt.Errorf("line %%d, column %%d: %%w", node.Line, node.Column, err)
+	}
+	return nil
+}
+`
+
+func (g *Generator) buildYAMLNodeMethods(typeName string, decode string, guard string) {
+	g.Printf(yamlNodeMethods, typeName, decode, guard)
 }
 
 // Arguments to format are: [1]: type name
@@ -238,7 +496,11 @@ func (g *Generator) buildFlagMethods(runs [][]Value, typeName string, runsThresh
 	g.Printf(flagValueMethodSet, typeName)
 }
 
-func (g *Generator) buildPflagMethods(runs [][]Value, typeName string, runsThreshold int) {
+func (g *Generator) buildPflagMethods(runs [][]Value, typeName string, runsThreshold int, shortType bool) {
 	g.Printf(flagValueMethodSet, typeName)
+	if shortType {
+		g.Printf(pflagShortTypeMethods, typeName)
+		return
+	}
 	g.Printf(pflagValueMethodType, typeName)
 }
@@ -0,0 +1,34 @@
+package main
+
+// Arguments to format are: [1]: type name [2]: name of the function decoding strings
+const envMethods = `
+// Decode implements the envconfig.Decoder interface for %[1]s
+func (i *%[1]s) Decode(value string) error {
+	val, err := _%[1]sDecodeEnv(value)
+	if err != nil {
+		return err
+	}
+	*i = val
+	return nil
+}
+
+// %[1]sEnvParser parses the value of an environment variable of type %[1]s,
+// it can be registered in the FuncMap of caarlos0/env as an env.ParserFunc.
+func %[1]sEnvParser(value string) (interface{}, error) {
+	return _%[1]sDecodeEnv(value)
+}
+
+// _%[1]sDecodeEnv decodes the value of an environment variable, the error lists
+// the names it accepts.
+func _%[1]sDecodeEnv(value string) (%[1]s, error) {
+	val, err := %[2]s(value)
+	if err != nil {
+		return val, fmt.Errorf("%%w, allowed values: %%s", err, strings.Join(_%[1]sNames, ", "))
+	}
+	return val, nil
+}
+`
+
+func (g *Generator) buildEnvMethods(typeName string, decode string) {
+	g.Printf(envMethods, typeName, decode)
+}
@@ -0,0 +1,44 @@
+package main
+
+import "fmt"
+
+// Arguments to format are: [1]: type name [2]: underlying type [3]: verb of the underlying value
+// [4]: case of the verbs printing the underlying value [5]: doc sentence of these verbs
+const formatMethod = `
+// Format implements the fmt.Formatter interface for %[1]s. %%v and %%s print the
+// name, %%q the quoted name and %%+v the name followed by the value, such as
+// Name(3).%[5]s
+// Values that are not part of the enum and other verbs print as
+// %%!<verb>(%[1]s=<value>).
+func (i %[1]s) Format(f fmt.State, verb rune) {
+	switch verb {
+%[4]s	case 'v', 's', 'q':
+		if !i.IsA%[1]s() {
+			break
+		}
+		if verb == 'v' && f.Flag('+') {
+			fmt.Fprintf(f, "%%s(%[3]s)", i.String(), %[2]s(i))
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, verb), i.String())
+		return
+	}
+	fmt.Fprintf(f, "%%%%!%%c(%[1]s=%[3]s)", verb, %[2]s(i))
+}
+`
+
+// Arguments to format are: [1]: underlying type
+const formatIntegerVerbs = `	case 'd', 'b', 'o', 'O', 'x', 'X':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), %[1]s(i))
+		return
+`
+
+func (g *Generator) buildFormatMethod(typeName string, isString bool) {
+	underlying := g.underlyingTypeName(typeName)
+	if isString {
+		g.Printf(formatMethod, typeName, underlying, "%q", "", "")
+		return
+	}
+	g.Printf(formatMethod, typeName, underlying, "%d", fmt.Sprintf(formatIntegerVerbs, underlying),
+		" The integer verbs, such as %d, print the value.")
+}
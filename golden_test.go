@@ -52,6 +52,10 @@ var goldenGQLGen = []Golden{
 	{"primeGQLGen", primeGQLGenIn},
 }
 
+var goldenGQLGenContext = []Golden{
+	{"primeGQLGenContext", primeGQLGenIn},
+}
+
 var goldenJSONAndSQL = []Golden{
 	{"primeJsonAndSql", primeJsonAndSqlIn},
 }
@@ -88,6 +92,170 @@ var goldenTypedErrors = []Golden{
 	{"typedErrors", typedErrorsIn},
 }
 
+var goldenSentinel = []Golden{
+	{"sentinel", sentinelIn},
+}
+
+var goldenBitflag = []Golden{
+	{"bitflag", bitflagIn},
+	{"bitflagSentinel", bitflagSentinelIn},
+}
+
+var goldenBitflagJSONArray = []Golden{
+	{"bitflagJsonArray", bitflagIn},
+}
+
+var goldenStringType = []Golden{
+	{"stringType", stringTypeIn},
+	{"stringTypeCase", stringTypeCaseIn},
+}
+
+var goldenAliases = []Golden{
+	{"aliases", aliasesIn},
+}
+
+var goldenCaseSensitive = []Golden{
+	{"caseSensitive", aliasesIn},
+}
+
+var goldenDirectives = []Golden{
+	{"directives", directivesIn},
+}
+
+var goldenDefault = []Golden{
+	{"default", defaultIn},
+}
+
+var goldenJSONNumbers = []Golden{
+	{"jsonNumbers", dayIn},
+}
+
+var goldenJSONNumberOutput = []Golden{
+	{"jsonNumberOutput", defaultIn},
+}
+
+var goldenSQLInt = []Golden{
+	{"primeSqlInt", primeSqlIn},
+}
+
+var goldenNullable = []Golden{
+	{"nullable", dayIn},
+}
+
+var goldenNullableSlogAndFormatter = []Golden{
+	{"nullableSlogAndFormatter", dayIn},
+}
+
+var goldenPgx = []Golden{
+	{"pgx", dayIn},
+}
+
+var goldenYAMLv3 = []Golden{
+	{"primeYamlV3", primeYamlIn},
+}
+
+var goldenBSON = []Golden{
+	{"bson", dayIn},
+}
+
+var goldenBSONInt = []Golden{
+	{"bsonInt", defaultIn},
+}
+
+var goldenProto = []Golden{
+	{"proto", protoIn},
+}
+
+// The enum protoc-gen-go generates for:
+//
+//	enum Color {
+//		COLOR_UNSPECIFIED = 0;
+//		COLOR_RED = 1;
+//		COLOR_DARK_BLUE = 2;
+//		COLOR_CRIMSON = 1; // With option allow_alias.
+//	}
+var colorProtoEnum = &protoEnum{
+	path:     "example.com/gen/colorpb",
+	pkgName:  "colorpb",
+	typeName: "Color",
+	groups: [][]protoValue{
+		{{"Color_COLOR_UNSPECIFIED", "UNSPECIFIED"}},
+		{{"Color_COLOR_RED", "RED"}, {"Color_COLOR_CRIMSON", "CRIMSON"}},
+		{{"Color_COLOR_DARK_BLUE", "DARK_BLUE"}},
+	},
+}
+
+var goldenEmitProto = []Golden{
+	{"emitProto", emitProtoIn},
+	{"emitProtoShared", emitProtoIn},
+}
+
+// The .proto files the goldenEmitProto tests update, by test name.
+var previousProtos = map[string]string{
+	"emitProto":       previousShadeProto,
+	"emitProtoShared": previousSharedProto,
+}
+
+var goldenJSONSchema = []Golden{
+	{"jsonSchema", jsonSchemaIn},
+}
+
+var goldenEmitJSONSchema = []Golden{
+	{"emitJsonSchema", jsonSchemaIn},
+}
+
+var goldenEmitTS = []Golden{
+	{"emitTs", jsonSchemaIn},
+}
+
+var goldenEmitTSNumbers = []Golden{
+	{"emitTsNumbers", jsonSchemaIn},
+}
+
+var goldenEmitGraphQL = []Golden{
+	{"emitGraphql", graphqlIn},
+}
+
+var goldenBinary = []Golden{
+	{"binary", defaultIn},
+}
+
+var goldenUnumBinary = []Golden{
+	{"unumBinary", unumIn},
+}
+
+var goldenMsgpackAndCBOR = []Golden{
+	{"msgpackAndCbor", dayIn},
+}
+
+var goldenMsgpackAndCBORInt = []Golden{
+	{"msgpackAndCborInt", unumIn},
+}
+
+var goldenXML = []Golden{
+	{"primeXml", primeIn},
+}
+
+var goldenEnv = []Golden{
+	{"env", dayIn},
+}
+
+var goldenCompletion = []Golden{
+	{"completion", graphqlIn},
+}
+
+var goldenSlogAndFormatter = []Golden{
+	{"slogAndFormatter", dayIn},
+}
+
+var goldenStringTypeFormatter = []Golden{
+	{"stringTypeFormatter", stringTypeIn},
+}
+
+var goldenStrictMarshal = []Golden{
+	{"strictMarshal", dayIn},
+}
+
 // Each example starts with "type XXX [u]int", with a single space separating them.
 
 // Simple test: enumeration of type int starting at 0.
@@ -111,7 +279,7 @@ const (
 	One
 	Two
 	Three
-	AnotherOne = One  // Duplicate; note that AnotherOne doesn't appear below.
+	AnotherOne = One  // Duplicate; AnotherOne is only accepted as an alias.
 )
 `
 
@@ -155,14 +323,14 @@ const (
 `
 
 // Enough gaps to trigger a map implementation of the method.
-// Also includes a duplicate to test that it doesn't cause problems
+// Also includes a duplicate to test that it is kept as an alias.
 const primeIn = `type Prime int
 const (
 	p2 Prime = 2
 	p3 Prime = 3
 	p5 Prime = 5
 	p7 Prime = 7
-	p77 Prime = 7 // Duplicate; note that p77 doesn't appear below.
+	p77 Prime = 7 // Duplicate; p77 is only accepted as an alias.
 	p11 Prime = 11
 	p13 Prime = 13
 	p17 Prime = 17
@@ -181,7 +349,7 @@ const (
 	p3 Prime = 3
 	p5 Prime = 5
 	p7 Prime = 7
-	p77 Prime = 7 // Duplicate; note that p77 doesn't appear below.
+	p77 Prime = 7 // Duplicate; p77 is only accepted as an alias.
 	p11 Prime = 11
 	p13 Prime = 13
 	p17 Prime = 17
@@ -200,7 +368,7 @@ const (
 	p3 Prime = 3
 	p5 Prime = 5
 	p7 Prime = 7
-	p77 Prime = 7 // Duplicate; note that p77 doesn't appear below.
+	p77 Prime = 7 // Duplicate; p77 is only accepted as an alias.
 	p11 Prime = 11
 	p13 Prime = 13
 	p17 Prime = 17
@@ -219,7 +387,7 @@ const (
 	p3 Prime = 3
 	p5 Prime = 5
 	p7 Prime = 7
-	p77 Prime = 7 // Duplicate; note that p77 doesn't appear below.
+	p77 Prime = 7 // Duplicate; p77 is only accepted as an alias.
 	p11 Prime = 11
 	p13 Prime = 13
 	p17 Prime = 17
@@ -238,7 +406,7 @@ const (
 	p3 Prime = 3
 	p5 Prime = 5
 	p7 Prime = 7
-	p77 Prime = 7 // Duplicate; note that p77 doesn't appear below.
+	p77 Prime = 7 // Duplicate; p77 is only accepted as an alias.
 	p11 Prime = 11
 	p13 Prime = 13
 	p17 Prime = 17
@@ -257,7 +425,7 @@ const (
        p3 Prime = 3
        p5 Prime = 5
        p7 Prime = 7
-       p77 Prime = 7 // Duplicate; note that p77 doesn't appear below.
+       p77 Prime = 7 // Duplicate; p77 is only accepted as an alias.
        p11 Prime = 11
        p13 Prime = 13
        p17 Prime = 17
@@ -276,7 +444,7 @@ const (
 	p3 Prime = 3
 	p5 Prime = 5
 	p7 Prime = 7
-	p77 Prime = 7 // Duplicate; note that p77 doesn't appear below.
+	p77 Prime = 7 // Duplicate; p77 is only accepted as an alias.
 	p11 Prime = 11
 	p13 Prime = 13
 	p17 Prime = 17
@@ -333,6 +501,178 @@ const (
 )
 `
 
+// Constants computed from the flags otherwise than by "|", which are not flags.
+const bitflagSentinelIn = `type Perm uint
+const (
+	Read Perm = 1 << iota
+	Write
+	Exec
+	ReadWrite = Read | (Write)
+	maxPerm = Exec << 1
+	allPerms = maxPerm - 1
+	permCount = iota
+)
+`
+
+// A sentinel computed from the constants, which is not a value, and an alias, which is.
+const sentinelIn = `type Day int
+const (
+	Monday Day = iota
+	Tuesday
+	Wednesday
+	Today = Tuesday
+	numDays = Wednesday + 1
+)
+`
+
+// Bit flags, including a named combination.
+const bitflagIn = `type Perm uint
+const (
+	Read Perm = 1 << iota
+	Write
+	Exec
+	ReadWrite = Read | Write
+)
+`
+
+// Constants of a string type, including a duplicate.
+const stringTypeIn = `type Color string
+const (
+	Red Color = "red"
+	Green Color = "green"
+	Blue Color = "Blue"
+	Crimson Color = "red" // Duplicate; only Red is kept.
+)
+`
+
+// Constants of a string type differing only in case.
+const stringTypeCaseIn = `type Size string
+const (
+	Small Size = "s"
+	Large Size = "l"
+	ExtraLarge Size = "L"
+)
+`
+
+// Aliases, with the canonical name marked on one that isn't declared first.
+const aliasesIn = `type Pill int
+const (
+	Placebo Pill = iota
+	Aspirin
+	Ibuprofen
+	Paracetamol
+	Acetaminophen = Paracetamol //enumer:canonical
+	Tylenol = Paracetamol
+	Advil = Ibuprofen
+)
+`
+
+// Unknown names decoded to the constant marked default.
+const defaultIn = `type Channel int
+const (
+	Stable Channel = iota
+	Beta
+	Nightly
+	Unknown Channel = -1 //enumer:default
+)
+`
+
+// Values matched by name with the proto enum, whatever their numbers.
+const protoIn = `type Color int
+const (
+	ColorUnspecified Color = iota
+	ColorDarkBlue
+	ColorRed
+)
+`
+
+// A proto enum emitted without zero value, over one that had another value.
+const emitProtoIn = `type Shade int
+const (
+	ShadeLight Shade = iota + 1
+	ShadeDarkBlue
+	ShadeNavy = ShadeDarkBlue
+	ShadeBlack Shade = 5
+)
+`
+
+const previousShadeProto = `syntax = "proto3";
+
+package colors;
+
+enum Shade {
+  reserved 9 to 11;
+  reserved "SHADE_WHITE";
+
+  SHADE_UNSPECIFIED = 0;
+  SHADE_LIGHT = 1;
+  SHADE_DARK_BLUE = 2;
+  SHADE_GREY = 3;
+}
+`
+
+// A file with an enum of another type, kept as it is.
+const previousSharedProto = `syntax = "proto3";
+
+package colors;
+
+enum Tint {
+  reserved 2;
+  reserved "TINT_PINK";
+
+  TINT_UNSPECIFIED = 0;
+  TINT_PALE = 1;
+}
+
+enum Shade {
+  SHADE_UNSPECIFIED = 0;
+  SHADE_LIGHT = 1;
+}
+`
+
+// Values documented by doc and line comments, which leave the directives out.
+const jsonSchemaIn = `type OrderStatus int
+const (
+	// The order was placed and awaits payment.
+	OrderPending OrderStatus = iota
+	// The order was paid, it ships
+	// within two days.
+	//enumer:alias "paid"
+	OrderConfirmed
+	OrderShipped // Handed to the carrier.
+	OrderCancelled
+	OrderCanceled = OrderCancelled
+)
+`
+
+// Documented and deprecated values, of a type documented by its line comment.
+const graphqlIn = `type Priority int // The urgency of a "ticket".
+const (
+	// Handled when someone is free.
+	PriorityLow Priority = iota
+	PriorityNormal
+	// Handled the same day.
+	//
+	// Deprecated: use PriorityHigh, which has the same deadline.
+	PriorityUrgent
+	PriorityHigh
+	// Deprecated:
+	PriorityCritical //enumer:alias "blocker"
+)
+`
+
+// Names and aliases given by directives, which are not transformed.
+const directivesIn = `type Stage int
+const (
+	StageTodo Stage = iota
+	//enumer:name "in-progress"
+	//enumer:alias "in_progress", "wip"
+	StageInProgress
+	StageDone //enumer:alias "finished"
+	StageCompleted = StageDone //enumer:alias "closed"
+)
+`
+
 func TestGolden(t *testing.T) {
 	for _, test := range golden {
 		runGoldenTest(t, test, generateOptions{
@@ -377,6 +717,14 @@ func TestGolden(t *testing.T) {
 			transformMethod: "noop",
 		})
 	}
+	for _, test := range goldenGQLGenContext {
+		runGoldenTest(t, test, generateOptions{
+			includeGQLGen:   true,
+			gqlgenMode:      "context",
+			transformMethod: "noop",
+			useTypedErrors:  true,
+		})
+	}
 	for _, test := range goldenTrimPrefix {
 		runGoldenTest(t, test, generateOptions{
 			trimPrefix:      "Day",
@@ -427,12 +775,287 @@ func TestGolden(t *testing.T) {
 			useTypedErrors:  true,
 		})
 	}
+	for _, test := range goldenSentinel {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod: "noop",
+		})
+	}
+	for _, test := range goldenBitflag {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod:  "noop",
+			includeJSON:      true,
+			bitflag:          true,
+			bitflagSeparator: "|",
+		})
+	}
+	for _, test := range goldenBitflagJSONArray {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod:  "noop",
+			includeJSON:      true,
+			bitflag:          true,
+			bitflagSeparator: ",",
+			bitflagJSONArray: true,
+		})
+	}
+	for _, test := range goldenStringType {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod: "noop",
+			includeJSON:     true,
+			includeSQL:      true,
+		})
+	}
+	for _, test := range goldenAliases {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod: "snake",
+			includeAliases:  true,
+		})
+	}
+	for _, test := range goldenCaseSensitive {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod: "noop",
+			caseSensitive:   true,
+		})
+	}
+	for _, test := range goldenDirectives {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod: "snake",
+			trimPrefix:      "Stage",
+			includeAliases:  true,
+		})
+	}
+	for _, test := range goldenDefault {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod: "lower",
+			includeJSON:     true,
+			includeText:     true,
+			includeYAML:     true,
+			includeSQL:      true,
+			includeGQLGen:   true,
+		})
+	}
+	for _, test := range goldenJSONNumbers {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod: "noop",
+			includeJSON:     true,
+			jsonNumbers:     true,
+			useTypedErrors:  true,
+		})
+	}
+	for _, test := range goldenJSONNumberOutput {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod: "lower",
+			includeJSON:     true,
+			jsonOutput:      "number",
+		})
+	}
+	for _, test := range goldenSQLInt {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod: "noop",
+			includeSQL:      true,
+			sqlMode:         "int",
+		})
+	}
+	for _, test := range goldenNullable {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod: "noop",
+			includeJSON:     true,
+			includeText:     true,
+			includeYAML:     true,
+			includeSQL:      true,
+			nullable:        true,
+		})
+	}
+	for _, test := range goldenPgx {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod: "snake",
+			includePgx:      true,
+		})
+	}
+	for _, test := range goldenYAMLv3 {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod: "noop",
+			includeYAML:     true,
+			yamlMode:        "v3",
+			nullable:        true,
+		})
+	}
+	for _, test := range goldenBSON {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod: "noop",
+			bsonMode:        "string",
+			useTypedErrors:  true,
+		})
+	}
+	for _, test := range goldenBSONInt {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod: "lower",
+			bsonMode:        "int",
+			strictMarshal:   true,
+		})
+	}
+	for _, test := range goldenProto {
+		g := &Generator{proto: colorProtoEnum}
+		runGoldenTestWithGenerator(t, g, test, generateOptions{
+			transformMethod: "noop",
+			trimPrefix:      "Color",
+			protoType:       "example.com/gen/colorpb.Color",
+		}, (*Generator).format)
+	}
+	for _, test := range goldenEmitProto {
+		runGoldenTestWithGenerator(t, new(Generator), test, generateOptions{
+			transformMethod: "noop",
+			trimPrefix:      "Shade",
+		}, func(g *Generator) []byte {
+			previous, err := parseProtoEnums([]byte(previousProtos[test.name]))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return g.buildProtoFile("// Code generated by enumer.", "colors", previous)
+		})
+	}
+	for _, test := range goldenJSONSchema {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod:   "snake",
+			trimPrefix:        "Order",
+			includeJSON:       true,
+			includeJSONSchema: true,
+		})
+	}
+	for _, test := range goldenEmitJSONSchema {
+		runGoldenTestWithGenerator(t, new(Generator), test, generateOptions{
+			transformMethod: "snake",
+			trimPrefix:      "Order",
+			includeJSON:     true,
+		}, func(g *Generator) []byte {
+			return g.buildJSONSchemaFile("// Code generated by enumer.", generateOptions{includeJSON: true})
+		})
+	}
+	for _, test := range goldenEmitTS {
+		opts := generateOptions{transformMethod: "kebab", trimPrefix: "Order", includeText: true}
+		runGoldenTestWithGenerator(t, new(Generator), test, opts, func(g *Generator) []byte {
+			return g.buildTSFile("// Code generated by enumer.", true, opts)
+		})
+	}
+	for _, test := range goldenEmitTSNumbers {
+		opts := generateOptions{transformMethod: "noop", includeJSON: true, jsonOutput: "number"}
+		runGoldenTestWithGenerator(t, new(Generator), test, opts, func(g *Generator) []byte {
+			return g.buildTSFile("// Code generated by enumer.", true, opts)
+		})
+	}
+	for _, test := range goldenEmitGraphQL {
+		runGoldenTestWithGenerator(t, new(Generator), test, generateOptions{
+			transformMethod: "snake-upper",
+			trimPrefix:      "Priority",
+			includeGQLGen:   true,
+		}, func(g *Generator) []byte {
+			header := "// Code generated by enumer."
+			// The file of the test is outside of any module.
+			g.pkg.path = "example.com/tickets"
+			return append(g.buildGraphQLFile(header), g.buildGQLGenModels(header)...)
+		})
+	}
+	for _, test := range goldenBinary {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod: "noop",
+			includeBinary:   true,
+			strictMarshal:   true,
+		})
+	}
+	for _, test := range goldenUnumBinary {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod: "noop",
+			includeBinary:   true,
+			useTypedErrors:  true,
+		})
+	}
+	for _, test := range goldenMsgpackAndCBOR {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod: "noop",
+			msgpackMode:     "string",
+			cborMode:        "string",
+			strictMarshal:   true,
+		})
+	}
+	for _, test := range goldenMsgpackAndCBORInt {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod: "noop",
+			msgpackMode:     "int",
+			cborMode:        "int",
+			useTypedErrors:  true,
+		})
+	}
+	for _, test := range goldenXML {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod: "noop",
+			includeXML:      true,
+			strictMarshal:   true,
+			useTypedErrors:  true,
+		})
+	}
+	for _, test := range goldenEnv {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod: "noop",
+			includeEnv:      true,
+			caseSensitive:   true,
+			useTypedErrors:  true,
+		})
+	}
+	for _, test := range goldenCompletion {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod:     "kebab",
+			trimPrefix:          "Priority",
+			includePflagMethods: true,
+			pflagShortType:      true,
+			pflagCompletion:     true,
+		})
+	}
+	for _, test := range goldenSlogAndFormatter {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod:  "noop",
+			includeSlog:      true,
+			includeFormatter: true,
+		})
+	}
+	for _, test := range goldenNullableSlogAndFormatter {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod:  "noop",
+			includeJSON:      true,
+			includeSlog:      true,
+			includeFormatter: true,
+			nullable:         true,
+		})
+	}
+	for _, test := range goldenStringTypeFormatter {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod:  "noop",
+			includeSlog:      true,
+			includeFormatter: true,
+		})
+	}
+	for _, test := range goldenStrictMarshal {
+		runGoldenTest(t, test, generateOptions{
+			transformMethod: "noop",
+			includeJSON:     true,
+			includeText:     true,
+			includeYAML:     true,
+			includeSQL:      true,
+			strictMarshal:   true,
+			useTypedErrors:  true,
+		})
+	}
 }
 
 func runGoldenTest(t *testing.T, test Golden, opts generateOptions) {
 	t.Helper()
+	runGoldenTestWithGenerator(t, new(Generator), test, opts, (*Generator).format)
+}
+
+// runGoldenTestWithGenerator runs the test with a generator already holding
+// what generate would otherwise load, such as the -proto enum, and compares
+// the golden file with the output it returns, such as an emitted file.
+func runGoldenTestWithGenerator(t *testing.T, g *Generator, test Golden, opts generateOptions, output func(*Generator) []byte) {
+	t.Helper()
 
-	var g Generator
 	file := test.name + ".go"
 	input := "package test\n" + test.input
 
@@ -460,7 +1083,7 @@ func runGoldenTest(t *testing.T, test Golden, opts generateOptions) {
 	}
 	g.generate(tokens[1], opts)
 
-	got := string(g.format())
+	got := string(output(g))
 	expected, err := loadGolden(test.name)
 	if err != nil {
 		t.Fatalf("unexpected error while loading golden %q: %v", test.name, err)
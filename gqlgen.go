@@ -1,6 +1,6 @@
 package main
 
-// Arguments to format are: [1]: type name
+// Arguments to format are: [1]: type name [2]: name of the function decoding strings
 const gqlgenMethods = `
 // MarshalGQL implements the graphql.Marshaler interface for %[1]s
 func (i %[1]s) MarshalGQL(w io.Writer) {
@@ -15,11 +15,58 @@ func (i *%[1]s) UnmarshalGQL(value interface{}) error {
 	}
 
 	var err error
-	*i, err = %[1]sString(str)
+	*i, err = %[2]s(str)
 	return err
 }
 `
 
-func (g *Generator) buildGQLGenMethods(runs [][]Value, typeName string) {
-	g.Printf(gqlgenMethods, typeName)
+// Arguments to format are: [1]: type name [2]: name of the function decoding strings
+// [3]: error of the values that are not part of the enum
+const gqlgenContextMethods = `
+// MarshalGQLContext implements the graphql.ContextMarshaler interface for %[1]s
+func (i %[1]s) MarshalGQLContext(ctx context.Context, w io.Writer) error {
+	if !i.IsA%[1]s() {
+		return _%[1]sGQLError(ctx, %[3]s)
+	}
+	_, err := io.WriteString(w, strconv.Quote(i.String()))
+	return err
+}
+
+// UnmarshalGQLContext implements the graphql.ContextUnmarshaler interface for %[1]s
+func (i *%[1]s) UnmarshalGQLContext(ctx context.Context, value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return _%[1]sGQLError(ctx, fmt.Errorf("%[1]s should be a string, got %%T", value))
+	}
+
+	val, err := %[2]s(str)
+	if err != nil {
+		return _%[1]sGQLError(ctx, err)
+	}
+	*i = val
+	return nil
+}
+
+// _%[1]sGQLError returns err as a GraphQL error on the path of the field, listing
+// the allowed values in its message and in its allowedValues extension.
+func _%[1]sGQLError(ctx context.Context, err error) error {
+	return graphql.ErrorOnPath(ctx, &gqlerror.Error{
+		Err:     err,
+		Message: fmt.Sprintf("%%s, allowed values: %%s", err, strings.Join(_%[1]sNames, ", ")),
+		Extensions: map[string]interface{}{
+			"allowedValues": _%[1]sNames,
+		},
+	})
+}
+`
+
+func (g *Generator) buildGQLGenMethods(runs [][]Value, typeName string, decode string) {
+	g.Printf(gqlgenMethods, typeName, decode)
+}
+
+// buildGQLGenContextMethods generates the methods of the context-aware
+// interfaces of gqlgen, which can return errors and always reject invalid
+// values, on output too.
+func (g *Generator) buildGQLGenContextMethods(typeName string, decode string, useTypedErrors bool) {
+	g.Printf(gqlgenContextMethods, typeName, decode, invalidValueError(typeName, "i", useTypedErrors))
 }
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Arguments to format are: [1]: type name [2]: slice of the values MarshalJSON produces [3]: JSON type
+// [4]: expression converting the element v of the slice [5]: entries of the extra keywords
+const jsonSchemaMethod = `
+// JSONSchema implements the jsonschema.JSONSchemaer interface for %[1]s,
+// the enum lists the values MarshalJSON produces.
+func (%[1]s) JSONSchema() *jsonschema.Schema {
+	enum := make([]interface{}, len(%[2]s))
+	for i, v := range %[2]s {
+		enum[i] = %[4]s
+	}
+	return &jsonschema.Schema{
+		Type: %[3]q,
+		Enum: enum,
+		Extras: map[string]interface{}{
+%[5]s		},
+	}
+}
+`
+
+// jsonSchemaDescriptions returns the doc comments of the values, or nil if
+// none of them has one.
+func jsonSchemaDescriptions(values []Value) []string {
+	descriptions := make([]string, len(values))
+	found := false
+	for i, v := range values {
+		descriptions[i] = v.doc
+		found = found || v.doc != ""
+	}
+	if !found {
+		return nil
+	}
+	return descriptions
+}
+
+func (g *Generator) buildJSONSchemaMethod(runs [][]Value, typeName string, opts generateOptions) {
+	var values []Value
+	for _, run := range runs {
+		values = append(values, run...)
+	}
+	quote := func(strs []string) string {
+		quoted := make([]string, len(strs))
+		for i, s := range strs {
+			quoted[i] = fmt.Sprintf("%q", s)
+		}
+		return "[]string{" + strings.Join(quoted, ", ") + "}"
+	}
+
+	varNames := make([]string, len(values))
+	for i, v := range values {
+		varNames[i] = v.originalName
+	}
+	extras := fmt.Sprintf("\t\t\t\"x-enum-varnames\": %s,\n", quote(varNames))
+	if descriptions := jsonSchemaDescriptions(values); descriptions != nil {
+		extras += fmt.Sprintf("\t\t\t\"x-enum-descriptions\": %s,\n", quote(descriptions))
+	}
+
+	if jsonWritesNumbers(opts) {
+		g.Printf(jsonSchemaMethod, typeName, "_"+typeName+"Values", "integer", g.underlyingTypeName(typeName)+"(v)", extras)
+		return
+	}
+	g.Printf(jsonSchemaMethod, typeName, "_"+typeName+"Names", "string", "v", extras)
+}
+
+// jsonSchema is the subset of a JSON Schema written by -emit-jsonschema.
+type jsonSchema struct {
+	Schema       string                 `json:"$schema,omitempty"`
+	Comment      string                 `json:"$comment,omitempty"`
+	Defs         map[string]*jsonSchema `json:"$defs,omitempty"`
+	Type         string                 `json:"type,omitempty"`
+	Enum         []json.RawMessage      `json:"enum,omitempty"`
+	VarNames     []string               `json:"x-enum-varnames,omitempty"`
+	Descriptions []string               `json:"x-enum-descriptions,omitempty"`
+}
+
+// buildJSONSchemaFile returns the JSON Schema file defining the enums
+// generated so far in its $defs, with the values MarshalJSON produces. They
+// are the names of _<Type>Names or, for -json.output=number, the numbers.
+func (g *Generator) buildJSONSchemaFile(header string, opts generateOptions) []byte {
+	file := &jsonSchema{
+		Schema:  "https://json-schema.org/draft/2020-12/schema",
+		Comment: strings.TrimPrefix(header, "// "),
+		Defs:    make(map[string]*jsonSchema),
+	}
+	for _, e := range g.enums {
+		def := &jsonSchema{Type: "string", Descriptions: jsonSchemaDescriptions(e.values)}
+		if jsonWritesNumbers(opts) {
+			def.Type = "integer"
+		}
+		for _, v := range e.values {
+			value := json.RawMessage(v.str)
+			if !jsonWritesNumbers(opts) {
+				value, _ = json.Marshal(v.name)
+			}
+			def.Enum = append(def.Enum, value)
+			def.VarNames = append(def.VarNames, v.originalName)
+		}
+		file.Defs[e.typeName] = def
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(file); err != nil {
+		log.Fatalf("encoding the JSON schema: %s", err)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,58 @@
+package main
+
+// Arguments to format are: [1]: type name [2]: name of the function decoding strings [3]: strict marshaling guard
+const msgpackMethods = `
+// EncodeMsgpack implements the msgpack.CustomEncoder interface for %[1]s
+func (i %[1]s) EncodeMsgpack(enc *msgpack.Encoder) error {
+%[3]s	return enc.EncodeString(i.String())
+}
+
+// DecodeMsgpack implements the msgpack.CustomDecoder interface for %[1]s
+func (i *%[1]s) DecodeMsgpack(dec *msgpack.Decoder) error {
+	s, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("%[1]s should be a string: %%w", err)
+	}
+
+	*i, err = %[2]s(s)
+	return err
+}
+`
+
+// Arguments to format are: [1]: type name [2]: Int or Uint, the kind of integer encoded [3]: integer type
+// [4]: statement handling numbers that are not values of the type [5]: strict marshaling guard
+const msgpackIntMethods = `
+// EncodeMsgpack implements the msgpack.CustomEncoder interface for %[1]s
+func (i %[1]s) EncodeMsgpack(enc *msgpack.Encoder) error {
+%[5]s	return enc.Encode%[2]s(%[3]s(i))
+}
+
+// DecodeMsgpack implements the msgpack.CustomDecoder interface for %[1]s
+func (i *%[1]s) DecodeMsgpack(dec *msgpack.Decoder) error {
+	n, err := dec.Decode%[2]s64()
+	if err != nil {
+		return fmt.Errorf("%[1]s should be an integer: %%w", err)
+	}
+
+	val := %[1]s(n)
+	if %[3]s(val) != n || !val.IsA%[1]s() {
+		%[4]s
+	}
+	*i = val
+	return nil
+}
+`
+
+func (g *Generator) buildMsgpackMethods(typeName string, decode string, signed bool, opts generateOptions) {
+	guard := strictMarshalGuard(typeName, "return %s", opts)
+	if opts.msgpackMode != "int" {
+		g.Printf(msgpackMethods, typeName, decode, guard)
+		return
+	}
+	invalid := invalidNumberStatement(typeName, "fmt.Sprint(n)", opts)
+	if signed {
+		g.Printf(msgpackIntMethods, typeName, "Int", "int64", invalid, guard)
+		return
+	}
+	g.Printf(msgpackIntMethods, typeName, "Uint", "uint64", invalid, guard)
+}
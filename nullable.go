@@ -0,0 +1,183 @@
+package main
+
+// Arguments to format are: [1]: type name
+const nullableType = `
+// Null%[1]s is a %[1]s that may be null, such as a SQL NULL or a JSON or YAML null.
+type Null%[1]s struct {
+	%[1]s
+	Valid bool // Valid is true if %[1]s is not null
+}
+
+// String returns "null" if the Null%[1]s is null, and the name of its %[1]s otherwise.
+func (n Null%[1]s) String() string {
+	if !n.Valid {
+		return "null"
+	}
+	return n.%[1]s.String()
+}
+`
+
+// Arguments to format are: [1]: type name
+const nullableSQLMethods = `
+// Scan implements the sql.Scanner interface for Null%[1]s
+func (n *Null%[1]s) Scan(value interface{}) error {
+	if value == nil {
+		*n = Null%[1]s{}
+		return nil
+	}
+	err := n.%[1]s.Scan(value)
+	n.Valid = err == nil
+	return err
+}
+
+// Value implements the driver.Valuer interface for Null%[1]s
+func (n Null%[1]s) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.%[1]s.Value()
+}
+`
+
+// Arguments to format are: [1]: type name
+const nullableJSONMethods = `
+// MarshalJSON implements the json.Marshaler interface for Null%[1]s
+func (n Null%[1]s) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.%[1]s.MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Null%[1]s
+func (n *Null%[1]s) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = Null%[1]s{}
+		return nil
+	}
+	err := n.%[1]s.UnmarshalJSON(data)
+	n.Valid = err == nil
+	return err
+}
+`
+
+// Arguments to format are: [1]: type name
+const nullableTextMethods = `
+// MarshalText implements the encoding.TextMarshaler interface for Null%[1]s,
+// a null is empty.
+func (n Null%[1]s) MarshalText() ([]byte, error) {
+	if !n.Valid {
+		return []byte{}, nil
+	}
+	return n.%[1]s.MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for Null%[1]s,
+// an empty text is a null.
+func (n *Null%[1]s) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*n = Null%[1]s{}
+		return nil
+	}
+	err := n.%[1]s.UnmarshalText(text)
+	n.Valid = err == nil
+	return err
+}
+`
+
+// Arguments to format are: [1]: type name
+const nullableYAMLMethods = `
+// MarshalYAML implements a YAML Marshaler for Null%[1]s
+func (n Null%[1]s) MarshalYAML() (interface{}, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.%[1]s.MarshalYAML()
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for Null%[1]s
+func (n *Null%[1]s) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v interface{}
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		*n = Null%[1]s{}
+		return nil
+	}
+	err := n.%[1]s.UnmarshalYAML(unmarshal)
+	n.Valid = err == nil
+	return err
+}
+`
+
+// Arguments to format are: [1]: type name
+const nullableYAMLNodeMethods = `
+// MarshalYAML implements the yaml.Marshaler interface for Null%[1]s
+func (n Null%[1]s) MarshalYAML() (interface{}, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.%[1]s.MarshalYAML()
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for Null%[1]s.
+// yaml.v3 doesn't call it for a null, which leaves the Null%[1]s unchanged.
+func (n *Null%[1]s) UnmarshalYAML(node *yaml.Node) error {
+	err := n.%[1]s.UnmarshalYAML(node)
+	n.Valid = err == nil
+	return err
+}
+`
+
+// Arguments to format are: [1]: type name
+const nullableSlogMethod = `
+// LogValue implements the slog.LogValuer interface for Null%[1]s, a null is
+// logged as nil.
+func (n Null%[1]s) LogValue() slog.Value {
+	if !n.Valid {
+		return slog.AnyValue(nil)
+	}
+	return n.%[1]s.LogValue()
+}
+`
+
+// Arguments to format are: [1]: type name
+const nullableFormatMethod = `
+// Format implements the fmt.Formatter interface for Null%[1]s, a null prints
+// as the string "null".
+func (n Null%[1]s) Format(f fmt.State, verb rune) {
+	if !n.Valid {
+		fmt.Fprintf(f, fmt.FormatString(f, verb), "null")
+		return
+	}
+	n.%[1]s.Format(f, verb)
+}
+`
+
+// buildNullableType generates the Null<Type> wrapper, with the marshaling
+// methods of the enabled codecs handling null and delegating the rest to the
+// methods of the type.
+func (g *Generator) buildNullableType(typeName string, opts generateOptions) {
+	g.Printf(nullableType, typeName)
+	if opts.includeSQL {
+		g.Printf(nullableSQLMethods, typeName)
+	}
+	if opts.includeJSON {
+		g.Printf(nullableJSONMethods, typeName)
+	}
+	if opts.includeText {
+		g.Printf(nullableTextMethods, typeName)
+	}
+	if opts.yamlMode == "v3" {
+		g.Printf(nullableYAMLNodeMethods, typeName)
+	} else if opts.includeYAML {
+		g.Printf(nullableYAMLMethods, typeName)
+	}
+	if opts.includeSlog {
+		g.Printf(nullableSlogMethod, typeName)
+	}
+	if opts.includeFormatter {
+		g.Printf(nullableFormatMethod, typeName)
+	}
+}
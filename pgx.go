@@ -0,0 +1,91 @@
+package main
+
+// Arguments to format are: [1]: type name [2]: name of the function decoding strings
+// [3]: error expression of an invalid value v
+const pgxCodec = `
+// _%[1]sPgCodec is the pgtype.Codec of %[1]s. Values are exchanged as their
+// names in both the text and the binary formats, as Postgres enums are.
+type _%[1]sPgCodec struct{}
+
+func (_%[1]sPgCodec) FormatSupported(format int16) bool {
+	return format == pgtype.TextFormatCode || format == pgtype.BinaryFormatCode
+}
+
+func (_%[1]sPgCodec) PreferredFormat() int16 {
+	return pgtype.BinaryFormatCode
+}
+
+func (_%[1]sPgCodec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	if _, ok := value.(%[1]s); ok {
+		return _%[1]sPgEncodePlan{}
+	}
+	return nil
+}
+
+func (_%[1]sPgCodec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	if _, ok := target.(*%[1]s); ok {
+		return _%[1]sPgScanPlan{}
+	}
+	return nil
+}
+
+func (_%[1]sPgCodec) DecodeDatabaseSQLValue(m *pgtype.Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	if src == nil {
+		return nil, nil
+	}
+	return string(src), nil
+}
+
+func (_%[1]sPgCodec) DecodeValue(m *pgtype.Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+	return _%[1]sPgDecode(src)
+}
+
+type _%[1]sPgEncodePlan struct{}
+
+func (_%[1]sPgEncodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	v := value.(%[1]s)
+	if !v.IsA%[1]s() {
+		return nil, %[3]s
+	}
+	return append(buf, v.String()...), nil
+}
+
+type _%[1]sPgScanPlan struct{}
+
+func (_%[1]sPgScanPlan) Scan(src []byte, target any) error {
+	if src == nil {
+		return fmt.Errorf("cannot scan NULL into *%[1]s")
+	}
+	v, err := _%[1]sPgDecode(src)
+	if err != nil {
+		return err
+	}
+	*target.(*%[1]s) = v
+	return nil
+}
+
+// _%[1]sPgDecode looks the exact names up without copying src, and leaves
+// the other names to %[2]s.
+func _%[1]sPgDecode(src []byte) (%[1]s, error) {
+	if v, ok := _%[1]sNameToValueMap[string(src)]; ok {
+		return v, nil
+	}
+	return %[2]s(string(src))
+}
+
+// Register%[1]sPgType registers %[1]s as the Postgres type of the given name
+// and OID on m, typically an enum created with CREATE TYPE ... AS ENUM, and
+// makes it the type %[1]s values are sent as by default.
+func Register%[1]sPgType(m *pgtype.Map, name string, oid uint32) {
+	m.RegisterType(&pgtype.Type{Name: name, OID: oid, Codec: _%[1]sPgCodec{}})
+	var v %[1]s
+	m.RegisterDefaultPgType(v, name)
+}
+`
+
+func (g *Generator) buildPgxCodec(typeName string, decode string, useTypedErrors bool) {
+	g.Printf(pgxCodec, typeName, decode, invalidValueError(typeName, "v", useTypedErrors))
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"go/constant"
+	"go/token"
+	"go/types"
+	"log"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// protoEnum is an enum type of another package, typically generated by protoc,
+// that the values are converted to and from.
+type protoEnum struct {
+	path     string // Import path of the package.
+	pkgName  string
+	typeName string
+	groups   [][]protoValue // Constants grouped by value, in value order.
+}
+
+// protoValue is a constant of a protoEnum.
+type protoValue struct {
+	constName string // Name of the Go constant, such as Color_COLOR_RED.
+	name      string // Name of the proto value, without the prefixes, such as RED.
+}
+
+// Arguments to format are: [1]: type name [2]: proto type [3]: zero value of the proto type
+const toProtoMethod = `
+// ToProto returns the %[2]s of the same name as i, or %[3]s if i is not part of the enum.
+func (i %[1]s) ToProto() %[2]s {
+	switch i {
+`
+
+// Arguments to format are: [1]: type name [2]: proto type
+const fromProtoFunc = `
+// %[1]sFromProto returns the %[1]s value of the same name as p.
+// Throws an error if p is not part of the %[2]s enum.
+func %[1]sFromProto(p %[2]s) (%[1]s, error) {
+	switch p {
+`
+
+// loadProtoEnum loads the type given by spec, <import path>.<Type>, and its
+// constants from the package found from directory dir. The names of the
+// constants are stripped of the <Type>_ prefix protoc-gen-go adds, and of the
+// given prefix.
+func loadProtoEnum(dir, spec, trimPrefix string) *protoEnum {
+	i := strings.LastIndex(spec, ".")
+	if i <= 0 || i == len(spec)-1 {
+		log.Fatalf("-proto must be <import path>.<Type>, got %q", spec)
+	}
+	path, typeName := spec[:i], spec[i+1:]
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes,
+		Dir:  dir,
+	}
+	pkgs, err := packages.Load(cfg, path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(pkgs) != 1 {
+		log.Fatalf("error: %d packages found for -proto %s", len(pkgs), spec)
+	}
+	if len(pkgs[0].Errors) > 0 {
+		log.Fatalf("loading the package of -proto %s: %v", spec, pkgs[0].Errors[0])
+	}
+	pkg := pkgs[0]
+	obj, ok := pkg.Types.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok {
+		log.Fatalf("type %s not found in package %s", typeName, path)
+	}
+	if basic, ok := obj.Type().Underlying().(*types.Basic); !ok || basic.Info()&types.IsInteger == 0 {
+		log.Fatalf("-proto type %s must be an integer type", spec)
+	}
+
+	var consts []*types.Const
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if ok && c.Exported() && types.Identical(c.Type(), obj.Type()) {
+			consts = append(consts, c)
+		}
+	}
+	if len(consts) == 0 {
+		log.Fatalf("no values defined for type %s of -proto", spec)
+	}
+	sort.SliceStable(consts, func(i, j int) bool {
+		return constant.Compare(consts[i].Val(), token.LSS, consts[j].Val())
+	})
+
+	pe := &protoEnum{path: path, pkgName: pkg.Name, typeName: typeName}
+	for i, c := range consts {
+		v := protoValue{
+			constName: c.Name(),
+			name:      strings.TrimPrefix(strings.TrimPrefix(c.Name(), typeName+"_"), trimPrefix),
+		}
+		if i > 0 && constant.Compare(consts[i-1].Val(), token.EQL, c.Val()) {
+			last := len(pe.groups) - 1
+			pe.groups[last] = append(pe.groups[last], v)
+		} else {
+			pe.groups = append(pe.groups, []protoValue{v})
+		}
+	}
+	return pe
+}
+
+// normalizeProtoName returns the name values are matched by: case and
+// separators don't matter, so that RED_ALERT matches RedAlert or red-alert.
+func normalizeProtoName(s string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "", " ", "").Replace(s))
+}
+
+// matchProtoValues returns the index of the group of proto constants each
+// value has the name of, in the order of the values. Each value, by its name or
+// one of its aliases, has to match exactly one group, and the other way round.
+func matchProtoValues(values []Value, typeName string, pe *protoEnum) []int {
+	protoType := pe.pkgName + "." + pe.typeName
+	groupOf := make(map[string]int)
+	for i, group := range pe.groups {
+		for _, v := range group {
+			name := normalizeProtoName(v.name)
+			if j, ok := groupOf[name]; ok && j != i {
+				log.Fatalf("%s and %s of %s have the same name", pe.groups[j][0].constName, v.constName, protoType)
+			}
+			groupOf[name] = i
+		}
+	}
+
+	matches := make([]int, len(values))
+	matchedBy := make(map[int]string)
+	for i, value := range values {
+		matches[i] = -1
+		for _, name := range append([]string{value.name}, aliasNames(value)...) {
+			j, ok := groupOf[normalizeProtoName(name)]
+			if !ok || j == matches[i] {
+				continue
+			}
+			if matches[i] >= 0 {
+				log.Fatalf("%s matches both %s and %s of %s", value.originalName, pe.groups[matches[i]][0].constName, pe.groups[j][0].constName, protoType)
+			}
+			matches[i] = j
+		}
+		if matches[i] < 0 {
+			log.Fatalf("%s of type %s has no counterpart in %s", value.originalName, typeName, protoType)
+		}
+		if other, ok := matchedBy[matches[i]]; ok {
+			log.Fatalf("%s and %s of type %s both match %s", other, value.originalName, typeName, pe.groups[matches[i]][0].constName)
+		}
+		matchedBy[matches[i]] = value.originalName
+	}
+	for j, group := range pe.groups {
+		if _, ok := matchedBy[j]; !ok {
+			log.Fatalf("%s of %s has no counterpart in type %s", group[0].constName, protoType, typeName)
+		}
+	}
+	return matches
+}
+
+// aliasNames returns the names of the aliases of the value.
+func aliasNames(value Value) []string {
+	names := make([]string, len(value.aliases))
+	for i, alias := range value.aliases {
+		names[i] = alias.name
+	}
+	return names
+}
+
+// buildProtoConversions generates the ToProto method and the <Type>FromProto
+// function, converting the values to and from the proto enum of the same names.
+func (g *Generator) buildProtoConversions(runs [][]Value, typeName, zero string, opts generateOptions) {
+	if g.pkg.typesPkg.Path() == g.proto.path {
+		log.Fatalf("-proto type %s must be in another package", opts.protoType)
+	}
+	var values []Value
+	for _, run := range runs {
+		values = append(values, run...)
+	}
+	matches := matchProtoValues(values, typeName, g.proto)
+	protoType := g.proto.pkgName + "." + g.proto.typeName
+
+	g.Printf(toProtoMethod, typeName, protoType, protoType+"(0)")
+	for i, value := range values {
+		g.Printf("\tcase %s:\n\t\treturn %s.%s\n", value.originalName, g.proto.pkgName, g.proto.groups[matches[i]][0].constName)
+	}
+	g.Printf("\t}\n\treturn 0\n}\n")
+
+	g.Printf(fromProtoFunc, typeName, protoType)
+	for i, value := range values {
+		g.Printf("\tcase %s.%s:\n\t\treturn %s, nil\n", g.proto.pkgName, g.proto.groups[matches[i]][0].constName, value.originalName)
+	}
+	g.Printf("\t}\n\treturn %s, %s\n}\n", zero, invalidValueError(typeName, "p", opts.useTypedErrors))
+}
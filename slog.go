@@ -0,0 +1,22 @@
+package main
+
+// Arguments to format are: [1]: type name [2]: underlying type [3]: verb of the underlying value
+const slogMethod = `
+// LogValue implements the slog.LogValuer interface for %[1]s, so the name is
+// logged whatever the handler. Values that are not part of the enum are logged
+// as %%!v(%[1]s=<value>).
+func (i %[1]s) LogValue() slog.Value {
+	if !i.IsA%[1]s() {
+		return slog.StringValue(fmt.Sprintf("%%%%!v(%[1]s=%[3]s)", %[2]s(i)))
+	}
+	return slog.StringValue(i.String())
+}
+`
+
+func (g *Generator) buildSlogMethod(typeName string, isString bool) {
+	underlying, verb := g.underlyingTypeName(typeName), "%d"
+	if isString {
+		verb = "%q"
+	}
+	g.Printf(slogMethod, typeName, underlying, verb)
+}
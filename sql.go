@@ -1,11 +1,14 @@
 package main
 
-// Arguments to format are: [1]: type name
+import "fmt"
+
+// Arguments to format are: [1]: type name [2]: strict marshaling guard
 const valueMethod = `func (i %[1]s) Value() (driver.Value, error) {
-	return i.String(), nil
+%[2]s	return i.String(), nil
 }
 `
 
+// Arguments to format are: [1]: type name [2]: name of the function decoding strings [3]: int64 case
 const scanMethod = `func (i *%[1]s) Scan(value interface{}) error {
 	if value == nil {
 		return nil
@@ -19,11 +22,11 @@ const scanMethod = `func (i *%[1]s) Scan(value interface{}) error {
 		str = v
 	case fmt.Stringer:
 		str = v.String()
-	default:
+%[3]s	default:
 		return fmt.Errorf("invalid value of %[1]s: %%[1]T(%%[1]v)", value)
 	}
 
-	val, err := %[1]sString(str)
+	val, err := %[2]s(str)
 	if err != nil {
 		return err
 	}
@@ -33,9 +36,72 @@ const scanMethod = `func (i *%[1]s) Scan(value interface{}) error {
 }
 `
 
-func (g *Generator) addValueAndScanMethod(typeName string) {
+// Arguments to format are: [1]: type name
+const scanInt64Case = `	case int64:
+		// The column may still hold the numbers of the values.
+		if val := %[1]s(v); int64(val) == v && val.IsA%[1]s() {
+			*i = val
+			return nil
+		}
+		str = fmt.Sprint(v)
+`
+
+// Arguments to format are: [1]: type name [2]: strict marshaling guard
+const valueIntMethod = `func (i %[1]s) Value() (driver.Value, error) {
+%[2]s	return int64(i), nil
+}
+`
+
+// Arguments to format are: [1]: type name [2]: statement handling numbers that are not values of the type
+const scanIntMethod = `func (i *%[1]s) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var n int64
+	switch v := value.(type) {
+	case int64:
+		n = v
+	case []byte:
+		var err error
+		if n, err = strconv.ParseInt(string(v), 10, 64); err != nil {
+			return fmt.Errorf("invalid value of %[1]s: %%q", v)
+		}
+	case string:
+		var err error
+		if n, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return fmt.Errorf("invalid value of %[1]s: %%q", v)
+		}
+	default:
+		return fmt.Errorf("invalid value of %[1]s: %%[1]T(%%[1]v)", value)
+	}
+
+	val := %[1]s(n)
+	if int64(val) != n || !val.IsA%[1]s() {
+		%[2]s
+	}
+
+	*i = val
+	return nil
+}
+`
+
+func (g *Generator) addValueAndScanMethod(typeName string, decode string, guard string, isString bool) {
+	int64Case := ""
+	if !isString {
+		int64Case = fmt.Sprintf(scanInt64Case, typeName)
+	}
+	g.Printf("\n")
+	g.Printf(valueMethod, typeName, guard)
+	g.Printf("\n\n")
+	g.Printf(scanMethod, typeName, decode, int64Case)
+}
+
+// addIntValueAndScanMethod generates the Valuer and Scanner methods storing
+// the numbers of the values rather than their names.
+func (g *Generator) addIntValueAndScanMethod(typeName string, guard string, opts generateOptions) {
 	g.Printf("\n")
-	g.Printf(valueMethod, typeName)
+	g.Printf(valueIntMethod, typeName, guard)
 	g.Printf("\n\n")
-	g.Printf(scanMethod, typeName)
+	g.Printf(scanIntMethod, typeName, invalidNumberStatement(typeName, "strconv.FormatInt(n, 10)", opts))
 }
@@ -43,12 +43,54 @@ func (af *arrayFlags) Set(value string) error {
 	return nil
 }
 
+// modeFlag is a flag selecting one of several modes. Given alone, as a boolean
+// flag, it selects the first one.
+type modeFlag struct {
+	mode  *string
+	modes []string
+}
+
+func (f modeFlag) String() string {
+	if f.mode == nil {
+		return ""
+	}
+	return *f.mode
+}
+
+func (f modeFlag) Set(value string) error {
+	switch value {
+	case "true":
+		*f.mode = f.modes[0]
+		return nil
+	case "false":
+		*f.mode = ""
+		return nil
+	}
+	for _, mode := range f.modes {
+		if value == mode {
+			*f.mode = mode
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of %s", strings.Join(f.modes, ", "))
+}
+
+func (f modeFlag) IsBoolFlag() bool {
+	return true
+}
+
 type generateOptions struct {
 	includeJSON         bool
 	includeYAML         bool
 	includeSQL          bool
 	includeText         bool
+	includeBinary       bool
+	includeXML          bool
+	includeEnv          bool
+	includeSlog         bool
+	includeFormatter    bool
 	includeGQLGen       bool
+	gqlgenMode          string
 	transformMethod     string
 	trimPrefix          string
 	addPrefix           string
@@ -56,7 +98,35 @@ type generateOptions struct {
 	includeValuesMethod bool
 	includeFlagMethods  bool
 	includePflagMethods bool
+	pflagShortType      bool
+	pflagCompletion     bool
 	useTypedErrors      bool
+	includeAliases      bool
+	caseSensitive       bool
+	bitflag             bool
+	bitflagSeparator    string
+	bitflagJSONArray    bool
+	defaultValue        string
+	jsonNumbers         bool
+	jsonOutput          string
+	strictMarshal       bool
+	sqlMode             string
+	nullable            bool
+	includePgx          bool
+	yamlMode            string
+	bsonMode            string
+	msgpackMode         string
+	cborMode            string
+	protoType           string
+	protoTrimPrefix     string
+	emitProto           string
+	emitProtoPackage    string
+	includeJSONSchema   bool
+	emitJSONSchema      string
+	emitTS              string
+	emitTSZod           bool
+	emitGraphQL         string
+	emitGQLGenModels    string
 }
 
 var (
@@ -69,20 +139,51 @@ var (
 func init() {
 	flag.StringVar(&typeNames, "type", "", "comma-separated list of type names; must be set")
 
-	flag.BoolVar(&opts.includeSQL, "sql", false, "if true, the Scanner and Valuer interface will be implemented.")
+	flag.Var(modeFlag{&opts.sqlMode, []string{"string", "int"}}, "sql", "if set, the Scanner and Valuer interface will be implemented, storing the names (-sql or -sql=string) or the numbers (-sql=int) of the values.")
+	flag.Var(modeFlag{&opts.bsonMode, []string{"string", "int"}}, "bson", "if set, bson marshaling methods for the MongoDB driver v2 will be generated, storing the names (-bson or -bson=string) or the numbers (-bson=int) of the values.")
+	flag.Var(modeFlag{&opts.msgpackMode, []string{"string", "int"}}, "msgpack", "if set, msgpack encoding methods for github.com/vmihailenco/msgpack/v5 will be generated, storing the names (-msgpack or -msgpack=string) or the numbers (-msgpack=int) of the values.")
+	flag.Var(modeFlag{&opts.cborMode, []string{"string", "int"}}, "cbor", "if set, CBOR marshaling methods for github.com/fxamacker/cbor/v2 will be generated, storing the names (-cbor or -cbor=string) or the numbers (-cbor=int) of the values.")
 	flag.BoolVar(&opts.includeJSON, "json", false, "if true, json marshaling methods will be generated. Default: false")
-	flag.BoolVar(&opts.includeYAML, "yaml", false, "if true, yaml marshaling methods will be generated. Default: false")
+	flag.BoolVar(&opts.jsonNumbers, "json.numbers", false, "if true, the json unmarshaling method also accepts the numbers of the values. Default: false")
+	flag.StringVar(&opts.jsonOutput, "json.output", "string", "json marshaling output, string or number; number implies -json.numbers.")
+	flag.Var(modeFlag{&opts.yamlMode, []string{"v2", "v3"}}, "yaml", "if set, yaml marshaling methods will be generated, for gopkg.in/yaml.v2 (-yaml or -yaml=v2) or for the nodes of gopkg.in/yaml.v3 (-yaml=v3).")
+	flag.BoolVar(&opts.includeJSONSchema, "jsonschema", false, "if true, a JSONSchema method for github.com/invopop/jsonschema will be generated, describing the values of -json or -text. Default: false")
+	flag.StringVar(&opts.emitJSONSchema, "emit-jsonschema", "", "path of a JSON Schema file to write the enum definitions to. Default: \"\"")
+	flag.StringVar(&opts.emitTS, "emit-ts", "", "path of a TypeScript file to write the enum types to. Default: \"\"")
+	flag.BoolVar(&opts.emitTSZod, "emit-ts.zod", false, "if true, the -emit-ts file also declares a Zod schema for each type. Default: false")
+	flag.StringVar(&opts.protoType, "proto", "", "<import path>.<Type> of an enum generated by protoc, the values are converted to and from with ToProto and <Type>FromProto. Default: \"\"")
+	flag.StringVar(&opts.protoTrimPrefix, "proto.trimprefix", "", "prefix removed from the names of the -proto values before matching them, such as COLOR_. Default: \"\"")
+	flag.StringVar(&opts.emitProto, "emit-proto", "", "path of a .proto file to write the enum definitions to. Default: \"\"")
+	flag.StringVar(&opts.emitProtoPackage, "emit-proto.package", "", "package of the -emit-proto file. Default: the Go package name")
+	flag.BoolVar(&opts.includePgx, "pgx", false, "if true, a pgx v5 codec and a function registering the Postgres type will be generated. Default: false")
 	flag.BoolVar(&opts.includeText, "text", false, "if true, text marshaling methods will be generated. Default: false")
-	flag.BoolVar(&opts.includeGQLGen, "gqlgen", false, "if true, GraphQL marshaling methods for gqlgen will be generated. Default: false")
+	flag.BoolVar(&opts.includeSlog, "slog", false, "if true, a LogValue method for log/slog will be generated. Default: false")
+	flag.BoolVar(&opts.includeFormatter, "formatter", false, "if true, a Format method implementing fmt.Formatter will be generated. Default: false")
+	flag.BoolVar(&opts.includeEnv, "env", false, "if true, a Decode method for envconfig and a <Type>EnvParser function for caarlos0/env will be generated. Default: false")
+	flag.BoolVar(&opts.includeXML, "xml", false, "if true, xml marshaling methods of attributes and elements will be generated. Default: false")
+	flag.BoolVar(&opts.includeBinary, "binary", false, "if true, binary marshaling methods encoding the numbers of the values as varints will be generated. Default: false")
+	flag.Var(modeFlag{&opts.gqlgenMode, []string{"writer", "context"}}, "gqlgen", "if set, GraphQL marshaling methods for gqlgen will be generated, MarshalGQL and UnmarshalGQL (-gqlgen or -gqlgen=writer) or MarshalGQLContext and UnmarshalGQLContext (-gqlgen=context).")
+	flag.StringVar(&opts.emitGraphQL, "emit-graphql", "", "path of a GraphQL schema file to write the enum definitions of -gqlgen to. Default: \"\"")
+	flag.StringVar(&opts.emitGQLGenModels, "emit-graphql.models", "", "path of a gqlgen.yml snippet to write the models binding the -emit-graphql enums to. Default: \"\"")
 	flag.BoolVar(&opts.includeValuesMethod, "values", false, "if true, alternative string values method will be generated. Default: false")
 	flag.BoolVar(&opts.includeFlagMethods, "flag.value", false, "if true, ensure that the enumeration type implements stdlib flag.Value interface. Default: false")
 	flag.BoolVar(&opts.includePflagMethods, "pflag.value", false, "if true, ensure that the enumeration type implements pflag.Value interface, see: https://pkg.go.dev/github.com/spf13/pflag#Value  Default: false")
+	flag.BoolVar(&opts.pflagShortType, "pflag.shorttype", false, "if true, the Type method of -pflag.value returns the type name, and a <Type>Usage function adds the allowed values to the usage of a flag. Default: false")
+	flag.BoolVar(&opts.pflagCompletion, "pflag.completion", false, "if true, a <Type>CompletionFunc completing the names of the values for cobra will be generated. Default: false")
 	flag.StringVar(&output, "output", "", "output file name; default srcdir/<type>_string.go")
 	flag.StringVar(&opts.transformMethod, "transform", "noop", "enum item name transformation method. Default: noop")
 	flag.StringVar(&opts.trimPrefix, "trimprefix", "", "transform each item name by removing a prefix or comma separated list of prefixes. Default: \"\"")
 	flag.StringVar(&opts.addPrefix, "addprefix", "", "transform each item name by adding a prefix. Default: \"\"")
 	flag.BoolVar(&opts.lineComment, "linecomment", false, "use line comment text as printed text when present")
+	flag.BoolVar(&opts.nullable, "nullable", false, "if true, a Null<Type> wrapper handling SQL NULL and JSON and YAML null will be generated. Default: false")
+	flag.BoolVar(&opts.strictMarshal, "strictmarshal", false, "if true, the marshaling methods fail on values that are not part of the enum. Default: false")
 	flag.BoolVar(&opts.useTypedErrors, "typederrors", false, "if true, use typed errors for enum string conversion methods. Default: false")
+	flag.BoolVar(&opts.caseSensitive, "casesensitive", false, "if true, <Type>String and the unmarshaling methods only accept names of the exact case. Default: false")
+	flag.BoolVar(&opts.includeAliases, "aliases", false, "if true, a function returning the alias names of a value will be generated. Default: false")
+	flag.BoolVar(&opts.bitflag, "bitflag", false, "if true, the constants are treated as bit flags that can be combined. Default: false")
+	flag.StringVar(&opts.bitflagSeparator, "bitflag.separator", "|", "separator between the names of combined bit flags.")
+	flag.BoolVar(&opts.bitflagJSONArray, "bitflag.jsonarray", false, "if true, combined bit flags are marshaled to JSON as an array of names. Default: false")
+	flag.StringVar(&opts.defaultValue, "default", "", "constant the unmarshaling methods return for unknown names instead of an error. Default: \"\"")
 
 	flag.Var(&comments, "comment", "comments to include in generated code, can repeat. Default: \"\"")
 }
@@ -109,6 +210,53 @@ func main() {
 		os.Exit(2)
 	}
 	typs := strings.Split(typeNames, ",")
+	opts.includeSQL = opts.sqlMode != ""
+	opts.includeYAML = opts.yamlMode != ""
+	opts.includeGQLGen = opts.gqlgenMode != ""
+	if opts.jsonOutput != "string" && opts.jsonOutput != "number" {
+		log.Fatalf("-json.output must be string or number, got %q", opts.jsonOutput)
+	}
+	if (opts.jsonNumbers || opts.jsonOutput == "number") && !opts.includeJSON {
+		log.Fatalf("-json.numbers and -json.output=number change the methods of -json, which must be set")
+	}
+	if opts.bitflagJSONArray && (opts.jsonNumbers || opts.jsonOutput == "number") {
+		log.Fatalf("-bitflag.jsonarray can't be combined with -json.numbers or -json.output=number")
+	}
+	if opts.protoType != "" && len(typs) > 1 {
+		log.Fatalf("-proto can only be used with a single type")
+	}
+	if opts.defaultValue != "" && len(typs) > 1 {
+		log.Fatalf("-default can only be used with a single type, mark the constants with //enumer:default instead")
+	}
+	if opts.strictMarshal && opts.gqlgenMode == "writer" {
+		log.Fatalf("-strictmarshal can't be combined with -gqlgen=writer, MarshalGQL can't return an error; use -gqlgen=context")
+	}
+	if opts.nullable && (opts.includeGQLGen || opts.includeFlagMethods || opts.includePflagMethods || opts.includePgx || opts.bsonMode != "" || opts.protoType != "" || opts.includeJSONSchema || opts.includeBinary || opts.msgpackMode != "" || opts.cborMode != "" || opts.includeXML || opts.includeEnv) {
+		log.Fatalf("-nullable only handles null in the methods of -sql, -json, -text and -yaml, Null<Type> would inherit the others ignoring Valid: it can't be combined with -gqlgen, -flag.value, -pflag.value, -pgx, -bson, -proto, -jsonschema, -binary, -msgpack, -cbor, -xml or -env")
+	}
+	if opts.includeJSONSchema || opts.emitJSONSchema != "" {
+		checkJSONDescribed("-jsonschema and -emit-jsonschema", opts)
+	}
+	if opts.emitTS != "" {
+		checkJSONDescribed("-emit-ts", opts)
+	}
+	if (opts.emitGraphQL != "" || opts.emitGQLGenModels != "") && (!opts.includeGQLGen || opts.bitflag) {
+		log.Fatalf("-emit-graphql and -emit-graphql.models describe the enums of -gqlgen, which must be set without -bitflag")
+	}
+	if opts.pflagShortType && !opts.includePflagMethods {
+		log.Fatalf("-pflag.shorttype changes the methods of -pflag.value, which must be set")
+	}
+	if opts.bitflag {
+		if opts.bitflagSeparator == "" {
+			log.Fatalf("-bitflag.separator must not be empty")
+		}
+		if opts.includeFlagMethods || opts.includePflagMethods {
+			log.Fatalf("-bitflag can't be combined with -flag.value or -pflag.value, both generate a Set method")
+		}
+	}
+	if opts.bitflagJSONArray && (!opts.bitflag || !opts.includeJSON) {
+		log.Fatalf("-bitflag.jsonarray changes the JSON methods of -bitflag, both must be set")
+	}
 
 	// We accept either one directory or a list of files. Which do we have?
 	args := flag.Args()
@@ -134,7 +282,8 @@ func main() {
 	g.parsePackage(args, []string{})
 
 	// Print the header and package clause.
-	g.Printf("// Code generated by \"enumer %s\"; DO NOT EDIT.\n", strings.Join(os.Args[1:], " "))
+	header := fmt.Sprintf("// Code generated by \"enumer %s\"; DO NOT EDIT.", strings.Join(os.Args[1:], " "))
+	g.Printf("%s\n", header)
 	g.Printf("\n")
 	if comments.String() != "" {
 		g.Printf("// %s\n", comments.String())
@@ -146,18 +295,62 @@ func main() {
 		g.Printf("\t\"errors\"\n")
 		g.Printf("\t\"github.com/dmarkham/enumer/enumerrs\"\n")
 	}
+	if opts.gqlgenMode == "context" {
+		g.Printf("\t\"context\"\n")
+	}
 	g.Printf("\t\"fmt\"\n")
-	g.Printf("\t\"strings\"\n")
-	if opts.includeSQL {
+	if !opts.caseSensitive || opts.bitflag || opts.includePflagMethods || opts.gqlgenMode == "context" || opts.includeEnv || opts.pflagCompletion {
+		g.Printf("\t\"strings\"\n")
+	}
+	if opts.includeSQL || opts.includePgx {
 		g.Printf("\t\"database/sql/driver\"\n")
 	}
+	if opts.includeBinary {
+		g.Printf("\t\"encoding/binary\"\n")
+	}
+	if opts.includeXML {
+		g.Printf("\t\"encoding/xml\"\n")
+	}
+	if opts.includeSlog {
+		g.Printf("\t\"log/slog\"\n")
+	}
 	if opts.includeJSON {
 		g.Printf("\t\"encoding/json\"\n")
 	}
 	if opts.includeGQLGen {
 		g.Printf("\t\"io\"\n")
+	}
+	if opts.includeGQLGen || opts.sqlMode == "int" {
 		g.Printf("\t\"strconv\"\n")
 	}
+	if opts.includePgx {
+		g.Printf("\t\"github.com/jackc/pgx/v5/pgtype\"\n")
+	}
+	if opts.yamlMode == "v3" {
+		g.Printf("\t\"gopkg.in/yaml.v3\"\n")
+	}
+	if opts.bsonMode != "" {
+		g.Printf("\t\"go.mongodb.org/mongo-driver/v2/bson\"\n")
+	}
+	if opts.msgpackMode != "" {
+		g.Printf("\t\"github.com/vmihailenco/msgpack/v5\"\n")
+	}
+	if opts.cborMode != "" {
+		g.Printf("\t\"github.com/fxamacker/cbor/v2\"\n")
+	}
+	if opts.includeJSONSchema {
+		g.Printf("\t\"github.com/invopop/jsonschema\"\n")
+	}
+	if opts.pflagCompletion {
+		g.Printf("\t\"github.com/spf13/cobra\"\n")
+	}
+	if opts.gqlgenMode == "context" {
+		g.Printf("\t\"github.com/99designs/gqlgen/graphql\"\n")
+		g.Printf("\t\"github.com/vektah/gqlparser/v2/gqlerror\"\n")
+	}
+	if i := strings.LastIndex(opts.protoType, "."); i > 0 {
+		g.Printf("\t%q\n", opts.protoType[:i])
+	}
 	g.Printf(")\n")
 
 	// Run generate for each type.
@@ -193,6 +386,37 @@ func main() {
 	if err != nil {
 		log.Fatalf("moving tempfile to output file: %s", err)
 	}
+
+	if opts.emitProto != "" {
+		protoPackage := opts.emitProtoPackage
+		if protoPackage == "" {
+			protoPackage = g.pkg.name
+		}
+		data := g.buildProtoFile(header, protoPackage, readProtoEnums(opts.emitProto))
+		if err := os.WriteFile(opts.emitProto, data, 0644); err != nil {
+			log.Fatalf("writing %s: %s", opts.emitProto, err)
+		}
+	}
+	if opts.emitJSONSchema != "" {
+		if err := os.WriteFile(opts.emitJSONSchema, g.buildJSONSchemaFile(header, opts), 0644); err != nil {
+			log.Fatalf("writing %s: %s", opts.emitJSONSchema, err)
+		}
+	}
+	if opts.emitTS != "" {
+		if err := os.WriteFile(opts.emitTS, g.buildTSFile(header, opts.emitTSZod, opts), 0644); err != nil {
+			log.Fatalf("writing %s: %s", opts.emitTS, err)
+		}
+	}
+	if opts.emitGraphQL != "" {
+		if err := os.WriteFile(opts.emitGraphQL, g.buildGraphQLFile(header), 0644); err != nil {
+			log.Fatalf("writing %s: %s", opts.emitGraphQL, err)
+		}
+	}
+	if opts.emitGQLGenModels != "" {
+		if err := os.WriteFile(opts.emitGQLGenModels, g.buildGQLGenModels(header), 0644); err != nil {
+			log.Fatalf("writing %s: %s", opts.emitGQLGenModels, err)
+		}
+	}
 }
 
 // isDirectory reports whether the named file is a directory.
@@ -207,8 +431,17 @@ func isDirectory(name string) bool {
 // Generator holds the state of the analysis. Primarily used to buffer
 // the output for format.Source.
 type Generator struct {
-	buf bytes.Buffer // Accumulated output.
-	pkg *Package     // Package we are scanning.
+	buf   bytes.Buffer // Accumulated output.
+	pkg   *Package     // Package we are scanning.
+	proto *protoEnum   // Enum of -proto, loaded once.
+	enums []enum       // Generated enums, for the files emitted besides the Go code.
+}
+
+// enum holds the values of a generated type.
+type enum struct {
+	typeName string
+	values   []Value // In value order, with their aliases.
+	doc      string  // The doc comment of the type, without the directives.
 }
 
 // Printf prints the string to the output
@@ -225,11 +458,13 @@ type File struct {
 	values      []Value // Accumulator for constant values of that type.
 	trimPrefix  string
 	lineComment bool
+	bitflag     bool // Whether combinations of the constants are values too.
 }
 
 // Package holds information about a Go package
 type Package struct {
 	dir      string
+	path     string
 	name     string
 	defs     map[*ast.Ident]types.Object
 	files    []*File
@@ -292,9 +527,14 @@ func (g *Generator) parsePackage(patterns []string, tags []string) {
 // addPackage adds a type checked Package and its syntax files to the generator.
 func (g *Generator) addPackage(pkg *packages.Package) {
 	g.pkg = &Package{
-		name:  pkg.Name,
-		defs:  pkg.TypesInfo.Defs,
-		files: make([]*File, len(pkg.Syntax)),
+		name:     pkg.Name,
+		path:     pkg.PkgPath,
+		defs:     pkg.TypesInfo.Defs,
+		files:    make([]*File, len(pkg.Syntax)),
+		typesPkg: pkg.Types,
+	}
+	if len(pkg.GoFiles) > 0 {
+		g.pkg.dir = filepath.Dir(pkg.GoFiles[0])
 	}
 
 	for i, file := range pkg.Syntax {
@@ -412,6 +652,9 @@ func (g *Generator) transformValueNames(values []Value, transformMethod string)
 	}
 
 	for i, v := range values {
+		if v.explicit {
+			continue
+		}
 		after := fn(v.name)
 		// If the original one was "" or the one before the transformation
 		// was "" (most commonly if linecomment defines it as empty) we
@@ -428,14 +671,18 @@ func (g *Generator) transformValueNames(values []Value, transformMethod string)
 // trimValueNames removes a prefix from each name
 func (g *Generator) trimValueNames(values []Value, prefix string) {
 	for i := range values {
-		values[i].name = strings.TrimPrefix(values[i].name, prefix)
+		if !values[i].explicit {
+			values[i].name = strings.TrimPrefix(values[i].name, prefix)
+		}
 	}
 }
 
 // prefixValueNames adds a prefix to each name
 func (g *Generator) prefixValueNames(values []Value, prefix string) {
 	for i := range values {
-		values[i].name = prefix + values[i].name
+		if !values[i].explicit {
+			values[i].name = prefix + values[i].name
+		}
 	}
 }
 
@@ -444,6 +691,7 @@ func (g *Generator) generate(typeName string, opts generateOptions) {
 	values := make([]Value, 0, 100)
 	for _, file := range g.pkg.files {
 		file.lineComment = opts.lineComment
+		file.bitflag = opts.bitflag
 		// Set the state for this run of the walker.
 		file.typeName = typeName
 		file.values = nil
@@ -457,6 +705,28 @@ func (g *Generator) generate(typeName string, opts generateOptions) {
 		log.Fatalf("no values defined for type %s", typeName)
 	}
 
+	opts.defaultValue = defaultValueName(values, typeName, opts.defaultValue)
+
+	if values[0].isString {
+		if opts.bitflag {
+			log.Fatalf("type %s is a string, it can't be used with -bitflag", typeName)
+		}
+		if opts.jsonNumbers || opts.jsonOutput == "number" || opts.sqlMode == "int" || opts.bsonMode == "int" || opts.msgpackMode == "int" || opts.cborMode == "int" || opts.includeBinary {
+			log.Fatalf("type %s is a string, its values have no number for -json.numbers, -json.output=number, -sql=int, -bson=int, -msgpack=int, -cbor=int or -binary", typeName)
+		}
+		if opts.trimPrefix != "" || opts.addPrefix != "" || opts.transformMethod != "noop" || opts.lineComment {
+			log.Fatalf("type %s is a string, its values are its names and can't be changed by -trimprefix, -addprefix, -transform or -linecomment", typeName)
+		}
+		values = dedupStringValues(values)
+		g.enums = append(g.enums, enum{typeName, values, g.pkg.typeDoc(typeName)})
+		g.buildStringType(values, typeName, opts)
+		if opts.includeValuesMethod {
+			g.buildAltStringValuesMethod(typeName)
+		}
+		g.buildCodecs([][]Value{values}, typeName, 0, opts)
+		return
+	}
+
 	for _, prefix := range strings.Split(opts.trimPrefix, ",") {
 		g.trimValueNames(values, prefix)
 	}
@@ -465,7 +735,20 @@ func (g *Generator) generate(typeName string, opts generateOptions) {
 
 	g.prefixValueNames(values, opts.addPrefix)
 
+	if opts.bitflag {
+		for _, v := range values {
+			if v.signed && int64(v.value) < 0 {
+				log.Fatalf("bit flag %s of type %s can't be negative", v.originalName, typeName)
+			}
+		}
+	}
+
 	runs := splitIntoRuns(values)
+	e := enum{typeName: typeName, doc: g.pkg.typeDoc(typeName)}
+	for _, run := range runs {
+		e.values = append(e.values, run...)
+	}
+	g.enums = append(g.enums, e)
 	// The decision of which pattern to use depends on the number of
 	// runs in the numbers. If there's only one, it's easy. For more than
 	// one, there's a tradeoff between complexity and size of the data
@@ -476,16 +759,19 @@ func (g *Generator) generate(typeName string, opts generateOptions) {
 	// rather than use yet another algorithm such as binary search,
 	// we punt and use a map. In any case, the likelihood of a map
 	// being necessary for any realistic example other than bitmasks
-	// is very low. And bitmasks probably deserve their own analysis,
-	// to be done some other day.
-	const runsThreshold = 10
+	// is very low. Bitmasks get their own analysis with the bitflag
+	// option, which always uses the map.
+	runsThreshold := 10
 	switch {
+	case opts.bitflag:
+		runsThreshold = 0
+		g.buildBitflag(runs, typeName, opts.bitflagSeparator, opts.caseSensitive)
 	case len(runs) == 1:
-		g.buildOneRun(runs, typeName)
+		g.buildOneRun(runs, typeName, opts.caseSensitive)
 	case len(runs) <= runsThreshold:
-		g.buildMultipleRuns(runs, typeName)
+		g.buildMultipleRuns(runs, typeName, opts.caseSensitive)
 	default:
-		g.buildMap(runs, typeName)
+		g.buildMap(runs, typeName, opts.caseSensitive)
 	}
 	if opts.includeValuesMethod {
 		g.buildAltStringValuesMethod(typeName)
@@ -493,46 +779,157 @@ func (g *Generator) generate(typeName string, opts generateOptions) {
 
 	g.buildNoOpOrderChangeDetect(runs, typeName)
 
-	g.buildBasicExtras(runs, typeName, runsThreshold, opts.useTypedErrors)
+	g.buildBasicExtras(runs, typeName, runsThreshold, opts)
+	if opts.bitflag {
+		g.buildBitflagMethods(typeName)
+	}
+	g.buildCodecs(runs, typeName, runsThreshold, opts)
+}
+
+// buildCodecs generates the optional marshaling and flag methods, which only
+// rely on the String method and the <Type>String function.
+func (g *Generator) buildCodecs(runs [][]Value, typeName string, runsThreshold int, opts generateOptions) {
+	// The unmarshaling methods decode the names with <Type>String, unless
+	// there's a default value for the names it doesn't know.
+	decode := typeName + "String"
+	if opts.defaultValue != "" && (opts.includeJSON || opts.includeText || opts.includeXML || opts.includeEnv || opts.includeYAML || opts.includeSQL || opts.includeGQLGen || opts.includePgx || opts.bsonMode != "" || opts.msgpackMode != "" || opts.cborMode != "") {
+		decode = "_" + typeName + "Decode"
+		g.Printf(decodeWithDefaultFunc, typeName, opts.defaultValue)
+	}
 	if opts.includeJSON {
-		g.buildJSONMethods(runs, typeName, runsThreshold, opts.useTypedErrors)
+		if opts.bitflag && opts.bitflagJSONArray {
+			g.buildBitflagJSONMethods(typeName, decode, strictMarshalGuard(typeName, "return nil, %s", opts))
+		} else {
+			g.buildJSONMethods(runs, typeName, decode, opts)
+		}
 	}
 	if opts.includeText {
-		g.buildTextMethods(runs, typeName, runsThreshold, opts.useTypedErrors)
+		g.buildTextMethods(runs, typeName, decode, strictMarshalGuard(typeName, "return nil, %s", opts))
+	}
+	if opts.includeXML {
+		g.buildXMLMethods(typeName, decode, opts)
+	}
+	if opts.includeBinary {
+		g.buildBinaryMethods(typeName, runs[0][0].signed, opts)
 	}
 	if opts.includeYAML {
-		g.buildYAMLMethods(runs, typeName, runsThreshold, opts.useTypedErrors)
+		guard := strictMarshalGuard(typeName, "return nil, %s", opts)
+		if opts.yamlMode == "v3" {
+			g.buildYAMLNodeMethods(typeName, decode, guard)
+		} else {
+			g.buildYAMLMethods(runs, typeName, decode, guard)
+		}
 	}
 	if opts.includeSQL {
-		g.addValueAndScanMethod(typeName)
+		guard := strictMarshalGuard(typeName, "return nil, %s", opts)
+		if opts.sqlMode == "int" {
+			g.addIntValueAndScanMethod(typeName, guard, opts)
+		} else {
+			g.addValueAndScanMethod(typeName, decode, guard, runs[0][0].isString)
+		}
 	}
-	if opts.includeGQLGen {
-		g.buildGQLGenMethods(runs, typeName)
+	if opts.bsonMode != "" {
+		g.buildBSONMethods(typeName, decode, opts)
+	}
+	if opts.msgpackMode != "" {
+		g.buildMsgpackMethods(typeName, decode, runs[0][0].signed, opts)
+	}
+	if opts.cborMode != "" {
+		g.buildCBORMethods(typeName, decode, runs[0][0].signed, opts)
+	}
+	if opts.includePgx {
+		g.buildPgxCodec(typeName, decode, opts.useTypedErrors)
+	}
+	if opts.includeJSONSchema {
+		g.buildJSONSchemaMethod(runs, typeName, opts)
+	}
+	if opts.gqlgenMode == "context" {
+		g.buildGQLGenContextMethods(typeName, decode, opts.useTypedErrors)
+	} else if opts.includeGQLGen {
+		g.buildGQLGenMethods(runs, typeName, decode)
+	}
+	if opts.includeEnv {
+		g.buildEnvMethods(typeName, decode)
 	}
 	if opts.includePflagMethods {
-		g.buildPflagMethods(runs, typeName, runsThreshold)
+		g.buildPflagMethods(runs, typeName, runsThreshold, opts.pflagShortType)
 	} else if opts.includeFlagMethods {
 		g.buildFlagMethods(runs, typeName, runsThreshold)
 	}
+	if opts.pflagCompletion {
+		g.buildCompletionFunc(runs, typeName, opts.caseSensitive)
+	}
+	if opts.includeSlog {
+		g.buildSlogMethod(typeName, runs[0][0].isString)
+	}
+	if opts.includeFormatter {
+		g.buildFormatMethod(typeName, runs[0][0].isString)
+	}
+	if opts.nullable {
+		g.buildNullableType(typeName, opts)
+	}
+	if opts.protoType != "" {
+		if g.proto == nil {
+			g.proto = loadProtoEnum(g.pkg.dir, opts.protoType, opts.protoTrimPrefix)
+		}
+		zero := "0"
+		if runs[0][0].isString {
+			zero = `""`
+		}
+		g.buildProtoConversions(runs, typeName, zero, opts)
+	}
+}
+
+// defaultValueName returns the name of the constant unknown names are decoded
+// to: the one given by the -default flag if any, otherwise the one marked with
+// an //enumer:default directive, or "" when there's none.
+func defaultValueName(values []Value, typeName, flagValue string) string {
+	if flagValue != "" {
+		for _, v := range values {
+			if v.originalName == flagValue {
+				return flagValue
+			}
+		}
+		log.Fatalf("-default %s is not a constant of type %s", flagValue, typeName)
+	}
+	name := ""
+	for _, v := range values {
+		if !v.isDefault {
+			continue
+		}
+		if name != "" {
+			log.Fatalf("both %s and %s are marked //enumer:default", name, v.originalName)
+		}
+		name = v.originalName
+	}
+	return name
 }
 
 // splitIntoRuns breaks the values into runs of contiguous sequences.
 // For example, given 1,2,3,5,6,7 it returns {1,2,3},{5,6,7}.
 // The input slice is known to be non-empty.
 func splitIntoRuns(values []Value) [][]Value {
-	// We use stable sort so the lexically first name is chosen for equal elements.
+	// We use stable sort so the lexically first name is chosen for equal elements,
+	// unless another one is marked canonical.
 	sort.Stable(byValue(values))
 	// Remove duplicates. Stable sort has put the one we want to print first,
 	// so use that one. The String method won't care about which named constant
-	// was the argument, so the first name for the given value is the only one to keep.
+	// was the argument, so the first name for the given value is the only one
+	// to keep; the others are kept as its aliases for parsing.
 	// We need to do this because identical values would cause the switch or map
 	// to fail to compile.
 	j := 1
 	for i := 1; i < len(values); i++ {
-		if values[i].value != values[i-1].value {
+		if values[i].value != values[j-1].value {
 			values[j] = values[i]
 			j++
+			continue
 		}
+		if values[i].canonical {
+			log.Fatalf("%s and %s have the same value, only one of them can be canonical", values[j-1].originalName, values[i].originalName)
+		}
+		values[j-1].aliases = append(values[j-1].aliases, values[i])
+		values[j-1].aliases = append(values[j-1].aliases, values[i].aliases...)
 	}
 	values = values[:j]
 	runs := make([][]Value, 0, 10)
@@ -570,9 +967,18 @@ type Value struct {
 	// this matters is when sorting.
 	// Much of the time the str field is all we need; it is printed
 	// by Value.String.
-	value  uint64 // Will be converted to int64 when needed.
-	signed bool   // Whether the constant is a signed type.
-	str    string // The string representation given by the "go/exact" package.
+	value    uint64 // Will be converted to int64 when needed.
+	signed   bool   // Whether the constant is a signed type.
+	isString bool   // Whether the constant is a string type; value and signed are unused then.
+	str      string // The string representation given by the "go/exact" package.
+	// Constants with the same value are aliases of the one printed by
+	// the String method, which is the first declared unless another one
+	// is marked canonical. Aliases are only accepted when parsing.
+	canonical bool
+	aliases   []Value
+	explicit  bool   // Whether the name was given by a directive, in which case it is not transformed.
+	isDefault bool   // Whether unknown names are decoded to this value.
+	doc       string // The doc comment of the constant, without the directives.
 }
 
 func (v *Value) String() string {
@@ -587,6 +993,10 @@ type byValue []Value
 func (b byValue) Len() int      { return len(b) }
 func (b byValue) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
 func (b byValue) Less(i, j int) bool {
+	if b[i].value == b[j].value {
+		// The constant marked as canonical goes first among equal values.
+		return b[i].canonical && !b[j].canonical
+	}
 	if b[i].signed {
 		return int64(b[i].value) < int64(b[j].value)
 	}
@@ -611,9 +1021,15 @@ func (f *File) genDecl(node ast.Node) bool {
 		vspec := spec.(*ast.ValueSpec) // Guaranteed to succeed as this is CONST.
 		if vspec.Type == nil && len(vspec.Values) > 0 {
 			// "X = 1". With no type but a value, the constant is untyped.
-			// Skip this vspec and reset the remembered type.
+			// Skip this vspec and reset the remembered type, unless it is
+			// an alias as in "X = Y", or combines bit flags as in "X = A | B":
+			// remember the type the checker found for it then, if it is one
+			// of ours. Sentinels such as "numDays = Sunday + 1" are still
+			// skipped.
 			typ = ""
-			continue
+			if isConstantName(vspec.Values) || (f.bitflag && isFlagCombination(vspec.Values)) {
+				typ = f.definedTypeName(vspec.Names[0])
+			}
 		}
 		if vspec.Type != nil {
 			// "X T". We have a type. Remember it.
@@ -630,6 +1046,16 @@ func (f *File) genDecl(node ast.Node) bool {
 		// We now have a list of names (from one line of source code) all being
 		// declared with the desired type.
 		// Grab their names and actual values and store them in f.values.
+		docs := []*ast.CommentGroup{vspec.Doc, vspec.Comment}
+		if !decl.Lparen.IsValid() {
+			// "const X T = 1". The doc comment belongs to the declaration.
+			docs = append(docs, decl.Doc)
+		}
+		dirs := parseDirectives(docs...)
+		doc := docText(docs, f.lineComment)
+		if dirs.name != "" && len(vspec.Names) > 1 {
+			log.Fatalf("can't give the same name to the %d constants declared along with %s", len(vspec.Names), vspec.Names[0])
+		}
 		for _, n := range vspec.Names {
 			if n.Name == "_" {
 				continue
@@ -642,10 +1068,24 @@ func (f *File) genDecl(node ast.Node) bool {
 				log.Fatalf("no value for constant %s", n)
 			}
 			info := obj.Type().Underlying().(*types.Basic).Info()
+			value := obj.(*types.Const).Val() // Guaranteed to succeed as this is CONST.
+			if info&types.IsString != 0 {
+				if dirs.name != "" {
+					log.Fatalf("can't rename %s with a directive: the value of a string constant is its name", n)
+				}
+				// The value of a string constant is the name it is printed as.
+				f.values = append(f.values, dirs.apply(Value{
+					originalName: n.Name,
+					name:         exact.StringVal(value),
+					isString:     true,
+					str:          value.String(),
+					doc:          doc,
+				}))
+				continue
+			}
 			if info&types.IsInteger == 0 {
-				log.Fatalf("can't handle non-integer constant type %s", typ)
+				log.Fatalf("can't handle non-integer, non-string constant type %s", typ)
 			}
-			value := obj.(*types.Const).Val() // Guaranteed to succeed as this is CONST.
 			if value.Kind() != exact.Int {
 				log.Fatalf("can't happen: constant is not an integer %s", n)
 			}
@@ -663,17 +1103,103 @@ func (f *File) genDecl(node ast.Node) bool {
 				value:        u64,
 				signed:       info&types.IsUnsigned == 0,
 				str:          value.String(),
+				doc:          doc,
 			}
-			if c := vspec.Comment; f.lineComment && c != nil && len(c.List) == 1 {
+			if c := vspec.Comment; f.lineComment && c != nil && len(c.List) == 1 && !isDirective(c.List[0]) {
 				v.name = strings.TrimSpace(c.Text())
 			}
 
-			f.values = append(f.values, v)
+			f.values = append(f.values, dirs.apply(v))
 		}
 	}
 	return false
 }
 
+// isConstantName reports whether the values are all plain names, such as the
+// Y of "X = Y".
+func isConstantName(values []ast.Expr) bool {
+	for _, v := range values {
+		if _, ok := ast.Unparen(v).(*ast.Ident); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// isFlagCombination reports whether the values only combine names, such as
+// the constants of "X = A | B", with "|".
+func isFlagCombination(values []ast.Expr) bool {
+	for _, v := range values {
+		switch v := ast.Unparen(v).(type) {
+		case *ast.Ident:
+		case *ast.BinaryExpr:
+			if v.Op != token.OR || !isFlagCombination([]ast.Expr{v.X, v.Y}) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// docText returns the text of the doc comment of a constant, the first of the
+// doc, line comment and declaration doc groups that has some. The line comment
+// is skipped when it gives the printed name. Directives are left out.
+func docText(groups []*ast.CommentGroup, lineComment bool) string {
+	for i, group := range groups {
+		if i == 1 && lineComment {
+			continue
+		}
+		if text := strings.TrimSpace(group.Text()); text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// typeDoc returns the text of the doc comment of the named type, or "" if it
+// has none.
+func (pkg *Package) typeDoc(typeName string) string {
+	for _, file := range pkg.files {
+		if file.file == nil {
+			continue
+		}
+		for _, decl := range file.file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				tspec := spec.(*ast.TypeSpec) // Guaranteed to succeed as this is TYPE.
+				if tspec.Name.Name != typeName {
+					continue
+				}
+				docs := []*ast.CommentGroup{tspec.Doc, tspec.Comment}
+				if !decl.Lparen.IsValid() {
+					docs = append(docs, decl.Doc)
+				}
+				return docText(docs, false)
+			}
+		}
+	}
+	return ""
+}
+
+// definedTypeName returns the name of the type the checker found for the
+// constant declared by n, or "" if it is not a named type of this package.
+func (f *File) definedTypeName(n *ast.Ident) string {
+	obj := f.pkg.defs[n]
+	if obj == nil {
+		return ""
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok || named.Obj().Pkg() != f.pkg.typesPkg {
+		return ""
+	}
+	return named.Obj().Name()
+}
+
 // Helpers
 
 // usize returns the number of bits of the smallest unsigned integer
@@ -692,15 +1218,18 @@ func usize(n int) int {
 }
 
 // declareIndexAndNameVars declares the index slices and concatenated names
-// strings representing the runs of values.
-func (g *Generator) declareIndexAndNameVars(runs [][]Value, typeName string) {
+// strings representing the runs of values. The lower case names are only
+// declared for case insensitive parsing.
+func (g *Generator) declareIndexAndNameVars(runs [][]Value, typeName string, caseSensitive bool) {
 	var indexes, names []string
 	for i, run := range runs {
 		index, n := g.createIndexAndNameDecl(run, typeName, fmt.Sprintf("_%d", i))
 		indexes = append(indexes, index)
 		names = append(names, n)
-		_, n = g.createLowerIndexAndNameDecl(run, typeName, fmt.Sprintf("_%d", i))
-		names = append(names, n)
+		if !caseSensitive {
+			_, n = g.createLowerIndexAndNameDecl(run, typeName, fmt.Sprintf("_%d", i))
+			names = append(names, n)
+		}
 	}
 	g.Printf("const (\n")
 	for _, n := range names {
@@ -715,10 +1244,13 @@ func (g *Generator) declareIndexAndNameVars(runs [][]Value, typeName string) {
 }
 
 // declareIndexAndNameVar is the single-run version of declareIndexAndNameVars
-func (g *Generator) declareIndexAndNameVar(run []Value, typeName string) {
+func (g *Generator) declareIndexAndNameVar(run []Value, typeName string, caseSensitive bool) {
 	index, n := g.createIndexAndNameDecl(run, typeName, "")
 	g.Printf("const %s\n", n)
 	g.Printf("var %s\n", index)
+	if caseSensitive {
+		return
+	}
 	index, n = g.createLowerIndexAndNameDecl(run, typeName, "")
 	g.Printf("const %s\n", n)
 	//g.Printf("var %s\n", index)
@@ -769,7 +1301,7 @@ func (g *Generator) createIndexAndNameDecl(run []Value, typeName string, suffix
 }
 
 // declareNameVars declares the concatenated names string representing all the values in the runs.
-func (g *Generator) declareNameVars(runs [][]Value, typeName string, suffix string) {
+func (g *Generator) declareNameVars(runs [][]Value, typeName string, suffix string, caseSensitive bool) {
 	g.Printf("const _%sName%s = \"", typeName, suffix)
 	for _, run := range runs {
 		for i := range run {
@@ -777,6 +1309,9 @@ func (g *Generator) declareNameVars(runs [][]Value, typeName string, suffix stri
 		}
 	}
 	g.Printf("\"\n")
+	if caseSensitive {
+		return
+	}
 	g.Printf("const _%sLowerName%s = \"", typeName, suffix)
 	for _, run := range runs {
 		for i := range run {
@@ -787,10 +1322,10 @@ func (g *Generator) declareNameVars(runs [][]Value, typeName string, suffix stri
 }
 
 // buildOneRun generates the variables and String method for a single run of contiguous values.
-func (g *Generator) buildOneRun(runs [][]Value, typeName string) {
+func (g *Generator) buildOneRun(runs [][]Value, typeName string, caseSensitive bool) {
 	values := runs[0]
 	g.Printf("\n")
-	g.declareIndexAndNameVar(values, typeName)
+	g.declareIndexAndNameVar(values, typeName, caseSensitive)
 	// The generated code is simple enough to write as a Printf format.
 	lessThanZero := ""
 	if values[0].signed {
@@ -831,9 +1366,9 @@ const stringOneRunWithOffset = `func (i %[1]s) String() string {
 
 // buildMultipleRuns generates the variables and String method for multiple runs of contiguous values.
 // For this pattern, a single Printf format won't do.
-func (g *Generator) buildMultipleRuns(runs [][]Value, typeName string) {
+func (g *Generator) buildMultipleRuns(runs [][]Value, typeName string, caseSensitive bool) {
 	g.Printf("\n")
-	g.declareIndexAndNameVars(runs, typeName)
+	g.declareIndexAndNameVars(runs, typeName, caseSensitive)
 	g.Printf("func (i %s) String() string {\n", typeName)
 	g.Printf("\tswitch {\n")
 	for i, values := range runs {
@@ -857,9 +1392,15 @@ func (g *Generator) buildMultipleRuns(runs [][]Value, typeName string) {
 
 // buildMap handles the case where the space is so sparse a map is a reasonable fallback.
 // It's a rare situation but has simple code.
-func (g *Generator) buildMap(runs [][]Value, typeName string) {
+func (g *Generator) buildMap(runs [][]Value, typeName string, caseSensitive bool) {
 	g.Printf("\n")
-	g.declareNameVars(runs, typeName, "")
+	g.declareNameVars(runs, typeName, "", caseSensitive)
+	g.declareMapVar(runs, typeName)
+	g.Printf(stringMap, typeName)
+}
+
+// declareMapVar declares the map from values to their names, sliced from the concatenated names string.
+func (g *Generator) declareMapVar(runs [][]Value, typeName string) {
 	g.Printf("\nvar _%sMap = map[%s]string{\n", typeName, typeName)
 	n := 0
 	for _, values := range runs {
@@ -869,7 +1410,6 @@ func (g *Generator) buildMap(runs [][]Value, typeName string) {
 		}
 	}
 	g.Printf("}\n\n")
-	g.Printf(stringMap, typeName)
 }
 
 // buildNoOpOrderChangeDetect try to let the compiler and the user know if the order/value of the ENUMS have changed.
@@ -885,6 +1425,13 @@ func (g *Generator) buildNoOpOrderChangeDetect(runs [][]Value, typeName string)
 	for _, values := range runs {
 		for _, value := range values {
 			g.Printf("\t_ = x[%s-(%s)]\n", value.originalName, value.str)
+			checked := map[string]bool{value.originalName: true}
+			for _, alias := range value.aliases {
+				if !checked[alias.originalName] {
+					checked[alias.originalName] = true
+					g.Printf("\t_ = x[%s-(%s)]\n", alias.originalName, alias.str)
+				}
+			}
 		}
 	}
 	g.Printf("}\n\n")
@@ -0,0 +1,83 @@
+package main
+
+import "strings"
+
+// Arguments to format are: [1]: type name
+const stringStringType = `func (i %[1]s) String() string {
+	return string(i)
+}
+`
+
+// dedupStringValues removes the constants whose value was already declared by
+// an earlier one, keeping the declaration order. The aliases of the removed
+// constants are given to the one kept.
+func dedupStringValues(values []Value) []Value {
+	kept := make(map[string]int, len(values))
+	j := 0
+	for _, v := range values {
+		if k, ok := kept[v.name]; ok {
+			// The names of the aliases are the same value, only the
+			// names given by directives are worth keeping.
+			values[k].aliases = append(values[k].aliases, v.aliases...)
+			continue
+		}
+		kept[v.name] = j
+		values[j] = v
+		j++
+	}
+	return values[:j]
+}
+
+// buildStringType generates the String method, the tables and the basic extra
+// methods for a type whose underlying type is string. The value of each
+// constant is also its name, so the tables refer to the constants themselves
+// and can't go stale: no name transformation applies to these types.
+func (g *Generator) buildStringType(values []Value, typeName string, opts generateOptions) {
+	g.Printf("\n")
+	g.Printf(stringStringType, typeName)
+
+	names := make([]string, len(values))
+	for i, value := range values {
+		names[i] = value.originalName
+	}
+	g.Printf("\nvar _%sValues = []%s{%s}\n\n", typeName, typeName, strings.Join(names, ", "))
+
+	g.Printf("\nvar _%sNames = []string{\n", typeName)
+	for _, value := range values {
+		g.Printf("\tstring(%s),\n", value.originalName)
+	}
+	g.Printf("}\n\n")
+
+	g.Printf("\nvar _%sNameToValueMap = map[string]%s{\n", typeName, typeName)
+	for _, value := range values {
+		g.Printf("\tstring(%s): %s,\n", value.originalName, value.originalName)
+	}
+	g.printAliasEntries([][]Value{values}, false)
+	g.Printf("}\n\n")
+
+	if !opts.caseSensitive {
+		// Values differing only in case, such as "red" and "RED", have the
+		// same lower case name, which parses as the first one declared.
+		g.Printf("\nvar _%sLowerNameToValueMap = map[string]%s{\n", typeName, typeName)
+		lowerNames := make(map[string]bool, len(values))
+		for _, value := range values {
+			lower := strings.ToLower(value.name)
+			if lowerNames[lower] {
+				continue
+			}
+			lowerNames[lower] = true
+			g.Printf("\t%q: %s,\n", lower, value.originalName)
+		}
+		g.printAliasEntries([][]Value{values}, true)
+		g.Printf("}\n\n")
+	}
+
+	if opts.includeAliases {
+		g.printAliasesMap([][]Value{values}, typeName)
+	}
+
+	g.printStringMethods(typeName, invalidValueError(typeName, "s", opts.useTypedErrors), `""`, opts.caseSensitive)
+	g.Printf(stringValuesMethod, typeName)
+	g.Printf(stringsMethod, typeName)
+	g.Printf(stringBelongsMethodLoop, typeName)
+}
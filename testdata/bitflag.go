@@ -0,0 +1,76 @@
+// Bit flags with a named combination.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Perm uint8
+
+const (
+	Read Perm = 1 << iota
+	Write
+	Exec
+	ReadWrite = Read | Write
+)
+
+func main() {
+	ck(0, "")
+	ck(Read, "Read")
+	ck(Write, "Write")
+	ck(ReadWrite, "ReadWrite")
+	ck(Read|Exec, "Read|Exec")
+	ck(Read|Write|Exec, "ReadWrite|Exec")
+	ck(Exec|64, "Exec|Perm(64)")
+	ckPermString("Read|Exec", Read|Exec)
+	ckPermString("exec | write", Write|Exec)
+	ckPermString("ReadWrite|Exec", Read|Write|Exec)
+	ckPermString("", 0)
+	if _, err := PermString("Read|Delete"); err == nil {
+		panic("bitflag.go: Read|Delete should not parse")
+	}
+	if !(Read | Exec).IsAPerm() || Perm(64).IsAPerm() {
+		panic("bitflag.go: IsAPerm")
+	}
+	p := Read.Set(Exec)
+	if !p.Has(Read|Exec) || p.Has(Write) || p.Clear(Read) != Exec || p.Toggle(Write|Exec) != ReadWrite {
+		panic("bitflag.go: flag methods")
+	}
+	ckJSON(Read|Write|Exec, `"ReadWrite|Exec"`)
+	ckJSON(0, `""`)
+}
+
+func ck(perm Perm, str string) {
+	if fmt.Sprint(perm) != str {
+		panic("bitflag.go: " + str)
+	}
+}
+
+func ckPermString(str string, perm Perm) {
+	p, err := PermString(str)
+	if err != nil {
+		panic("bitflag.go: " + err.Error())
+	}
+	if p != perm {
+		panic("bitflag.go: " + str)
+	}
+}
+
+func ckJSON(perm Perm, str string) {
+	data, err := json.Marshal(perm)
+	if err != nil {
+		panic("bitflag.go: " + err.Error())
+	}
+	if string(data) != str {
+		panic("bitflag.go: " + string(data))
+	}
+	var p Perm
+	if err := json.Unmarshal(data, &p); err != nil {
+		panic("bitflag.go: " + err.Error())
+	}
+	if p != perm {
+		panic("bitflag.go: round trip of " + str)
+	}
+}
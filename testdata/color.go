@@ -0,0 +1,58 @@
+// Constants of a string type.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Color string
+
+const (
+	Red     Color = "red"
+	Green   Color = "green"
+	Blue    Color = "Blue"
+	Crimson Color = "red"
+)
+
+func main() {
+	ck(Red, "red")
+	ck(Crimson, "red")
+	ck(Blue, "Blue")
+	ckColorString("green", Green)
+	ckColorString("GREEN", Green)
+	ckColorString("blue", Blue)
+	if _, err := ColorString("purple"); err == nil {
+		panic("color.go: purple should not parse")
+	}
+	if !Blue.IsAColor() || Color("purple").IsAColor() {
+		panic("color.go: IsAColor")
+	}
+	if len(ColorValues()) != 3 || len(ColorStrings()) != 3 {
+		panic("color.go: duplicates should be removed")
+	}
+	var c Color
+	if err := json.Unmarshal([]byte(`"Green"`), &c); err != nil || c != Green {
+		panic("color.go: UnmarshalJSON")
+	}
+	if err := json.Unmarshal([]byte(`"purple"`), &c); err == nil {
+		panic("color.go: UnmarshalJSON should validate")
+	}
+}
+
+func ck(color Color, str string) {
+	if fmt.Sprint(color) != str {
+		panic("color.go: " + str)
+	}
+}
+
+func ckColorString(str string, color Color) {
+	c, err := ColorString(str)
+	if err != nil {
+		panic("color.go: " + err.Error())
+	}
+	if c != color {
+		panic("color.go: " + str)
+	}
+}
@@ -0,0 +1,54 @@
+// XML marshaling of attributes and elements.
+
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+type Fruit int
+
+const (
+	Apple Fruit = iota
+	Banana
+	Cherry
+)
+
+type basket struct {
+	XMLName xml.Name `xml:"basket"`
+	Main    Fruit    `xml:"main,attr"`
+	Fruits  []Fruit  `xml:"fruit"`
+}
+
+func main() {
+	ck(Cherry, "Cherry")
+
+	data, err := xml.Marshal(basket{Main: Banana, Fruits: []Fruit{Apple, Cherry}})
+	if err != nil || string(data) != `<basket main="Banana"><fruit>Apple</fruit><fruit>Cherry</fruit></basket>` {
+		panic(fmt.Sprint("fruit.go: Marshal ", string(data), err))
+	}
+	var b basket
+	if err := xml.Unmarshal(data, &b); err != nil || b.Main != Banana || len(b.Fruits) != 2 || b.Fruits[1] != Cherry {
+		panic(fmt.Sprint("fruit.go: Unmarshal ", b, err))
+	}
+	if err := xml.Unmarshal([]byte(`<basket main="cherry"></basket>`), &b); err != nil || b.Main != Cherry {
+		panic(fmt.Sprint("fruit.go: Unmarshal of a lower case attribute ", err))
+	}
+
+	err = xml.Unmarshal([]byte(`<basket main="Kiwi"></basket>`), &b)
+	if err == nil || !strings.Contains(err.Error(), "attribute main") {
+		panic(fmt.Sprint("fruit.go: Unmarshal of an unknown attribute ", err))
+	}
+	err = xml.Unmarshal([]byte(`<basket><fruit>Kiwi</fruit></basket>`), &b)
+	if err == nil || !strings.Contains(err.Error(), "element fruit") {
+		panic(fmt.Sprint("fruit.go: Unmarshal of an unknown element ", err))
+	}
+}
+
+func ck(fruit Fruit, str string) {
+	if fmt.Sprint(fruit) != str {
+		panic("fruit.go: " + str)
+	}
+}
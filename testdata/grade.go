@@ -0,0 +1,83 @@
+// BSON numbers of a signed type, through the MongoDB driver.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dmarkham/enumer/enumerrs"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type Grade int8
+
+const (
+	Failing Grade = iota - 1
+	Passing
+	Honors
+)
+
+type record struct {
+	Grade Grade `bson:"grade"`
+}
+
+func main() {
+	ck(Failing, "Failing")
+
+	data, err := bson.Marshal(record{Failing})
+	if err != nil {
+		panic(fmt.Sprint("grade.go: MarshalBSONValue of Failing: ", err))
+	}
+	var stored struct {
+		Grade int64 `bson:"grade"`
+	}
+	if err := bson.Unmarshal(data, &stored); err != nil || stored.Grade != -1 {
+		panic(fmt.Sprint("grade.go: stored number of Failing: ", stored.Grade, err))
+	}
+	var r record
+	if err := bson.Unmarshal(data, &r); err != nil || r.Grade != Failing {
+		panic(fmt.Sprint("grade.go: UnmarshalBSONValue of Failing: ", r.Grade, err))
+	}
+	// Without -strictmarshal, an invalid value is written but can't be read back.
+	data, err = bson.Marshal(record{Grade(7)})
+	if err != nil {
+		panic(fmt.Sprint("grade.go: MarshalBSONValue of Grade(7): ", err))
+	}
+	if err := bson.Unmarshal(data, &r); !errors.Is(err, enumerrs.ErrValueInvalid) {
+		panic(fmt.Sprint("grade.go: UnmarshalBSONValue of Grade(7): ", err))
+	}
+
+	ckUnmarshal(struct{ Grade int32 }{1}, Honors)
+	ckUnmarshalError(struct{ Grade int32 }{5})
+	ckUnmarshalError(struct{ Grade int64 }{255}) // Grade(255) is -1.
+	ckUnmarshalError(struct{ Grade string }{"Honors"})
+}
+
+func ckUnmarshal(doc interface{}, grade Grade) {
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		panic(err)
+	}
+	var r record
+	if err := bson.Unmarshal(data, &r); err != nil || r.Grade != grade {
+		panic(fmt.Sprint("grade.go: UnmarshalBSONValue of ", doc, ": ", r.Grade, err))
+	}
+}
+
+func ckUnmarshalError(doc interface{}) {
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		panic(err)
+	}
+	var r record
+	if err := bson.Unmarshal(data, &r); err == nil {
+		panic(fmt.Sprint("grade.go: UnmarshalBSONValue of ", doc, " gives ", r.Grade))
+	}
+}
+
+func ck(grade Grade, str string) {
+	if fmt.Sprint(grade) != str {
+		panic("grade.go: " + str)
+	}
+}
@@ -0,0 +1,80 @@
+// MessagePack and CBOR names, through the codecs of their modules.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dmarkham/enumer/enumerrs"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type League int
+
+const (
+	Bronze League = iota
+	Silver
+	Gold
+)
+
+type codec struct {
+	name      string
+	marshal   func(v interface{}) ([]byte, error)
+	unmarshal func(data []byte, v interface{}) error
+}
+
+var codecs = []codec{
+	{"msgpack", msgpack.Marshal, msgpack.Unmarshal},
+	{"cbor", cbor.Marshal, cbor.Unmarshal},
+}
+
+func main() {
+	for _, c := range codecs {
+		ck(c, Bronze, "Bronze")
+		ck(c, Gold, "Gold")
+		ckUnmarshal(c, "silver", Silver)
+		ckUnmarshalError(c, "Tin")
+		ckUnmarshalError(c, 1)
+
+		// With -strictmarshal, an invalid value isn't written.
+		if _, err := c.marshal(League(7)); !errors.Is(err, enumerrs.ErrValueInvalid) {
+			panic(fmt.Sprintf("league.go: %s marshaling of League(7): %v", c.name, err))
+		}
+	}
+}
+
+func ck(c codec, league League, str string) {
+	data, err := c.marshal(league)
+	if err != nil {
+		panic(fmt.Sprintf("league.go: %s marshaling of %s: %v", c.name, str, err))
+	}
+	var s string
+	if err := c.unmarshal(data, &s); err != nil || s != str {
+		panic(fmt.Sprintf("league.go: %s name of %s: %q %v", c.name, str, s, err))
+	}
+	ckUnmarshal(c, str, league)
+}
+
+func ckUnmarshal(c codec, value interface{}, league League) {
+	data, err := c.marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	var got League
+	if err := c.unmarshal(data, &got); err != nil || got != league {
+		panic(fmt.Sprintf("league.go: %s unmarshaling of %v: %v %v", c.name, value, got, err))
+	}
+}
+
+func ckUnmarshalError(c codec, value interface{}) {
+	data, err := c.marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	var got League
+	if err := c.unmarshal(data, &got); err == nil {
+		panic(fmt.Sprintf("league.go: %s unmarshaling of %v gives %v", c.name, value, got))
+	}
+}
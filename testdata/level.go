@@ -0,0 +1,47 @@
+// Unknown names decoded to a default value.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Level int
+
+const (
+	LevelUnknown Level = iota
+	LevelLow
+	LevelHigh
+)
+
+func main() {
+	ck(LevelLow, "LevelLow")
+	var l Level
+	if err := json.Unmarshal([]byte(`"LevelHigh"`), &l); err != nil || l != LevelHigh {
+		panic("level.go: UnmarshalJSON of a known name")
+	}
+	if err := json.Unmarshal([]byte(`"LevelCritical"`), &l); err != nil || l != LevelUnknown {
+		panic("level.go: UnmarshalJSON of an unknown name")
+	}
+	l = LevelHigh
+	if err := l.UnmarshalText([]byte("LevelCritical")); err != nil || l != LevelUnknown {
+		panic("level.go: UnmarshalText of an unknown name")
+	}
+	l = LevelHigh
+	if err := l.Scan("LevelCritical"); err != nil || l != LevelUnknown {
+		panic("level.go: Scan of an unknown name")
+	}
+	if err := json.Unmarshal([]byte(`42`), &l); err == nil {
+		panic("level.go: UnmarshalJSON of a number")
+	}
+	if _, err := LevelString("LevelCritical"); err == nil {
+		panic("level.go: LevelString of an unknown name")
+	}
+}
+
+func ck(level Level, str string) {
+	if fmt.Sprint(level) != str {
+		panic("level.go: " + str)
+	}
+}
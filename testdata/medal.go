@@ -0,0 +1,72 @@
+// BSON names, through the MongoDB driver.
+
+package main
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type Medal int
+
+const (
+	Gold Medal = iota + 1
+	Silver
+	Bronze
+)
+
+type podium struct {
+	Medal Medal `bson:"medal"`
+}
+
+func main() {
+	ck(Gold, "Gold")
+
+	data, err := bson.Marshal(podium{Bronze})
+	if err != nil {
+		panic(fmt.Sprint("medal.go: MarshalBSONValue of Bronze: ", err))
+	}
+	var stored struct {
+		Medal string `bson:"medal"`
+	}
+	if err := bson.Unmarshal(data, &stored); err != nil || stored.Medal != "Bronze" {
+		panic(fmt.Sprint("medal.go: stored name of Bronze: ", stored.Medal, err))
+	}
+	var p podium
+	if err := bson.Unmarshal(data, &p); err != nil || p.Medal != Bronze {
+		panic(fmt.Sprint("medal.go: UnmarshalBSONValue of Bronze: ", p.Medal, err))
+	}
+
+	ckUnmarshal(struct{ Medal string }{"silver"}, Silver)
+	ckUnmarshalError(struct{ Medal string }{"Tin"})
+	ckUnmarshalError(struct{ Medal int32 }{1})
+}
+
+func ckUnmarshal(doc interface{}, medal Medal) {
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		panic(err)
+	}
+	var p podium
+	if err := bson.Unmarshal(data, &p); err != nil || p.Medal != medal {
+		panic(fmt.Sprint("medal.go: UnmarshalBSONValue of ", doc, ": ", p.Medal, err))
+	}
+}
+
+func ckUnmarshalError(doc interface{}) {
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		panic(err)
+	}
+	var p podium
+	if err := bson.Unmarshal(data, &p); err == nil {
+		panic(fmt.Sprint("medal.go: UnmarshalBSONValue of ", doc, " gives ", p.Medal))
+	}
+}
+
+func ck(medal Medal, str string) {
+	if fmt.Sprint(medal) != str {
+		panic("medal.go: " + str)
+	}
+}
@@ -0,0 +1,67 @@
+// Shell completion of the names of a flag, through cobra.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+type Mode int8
+
+const (
+	// Saves power.
+	//
+	// The CPU is throttled.
+	Eco Mode = iota - 1
+	Normal
+	// Runs as fast as it can.
+	Sport
+)
+
+func main() {
+	ckCompletion("", []string{"Eco\tSaves power.", "Normal", "Sport\tRuns as fast as it can."})
+	ckCompletion("s", []string{"Sport\tRuns as fast as it can."})
+	ckCompletion("Turbo", nil)
+
+	mode := Normal
+	cmd := &cobra.Command{Use: "drive", Run: func(*cobra.Command, []string) {}}
+	cmd.Flags().Var(&mode, "mode", ModeUsage("driving mode"))
+	if err := cmd.RegisterFlagCompletionFunc("mode", ModeCompletionFunc); err != nil {
+		panic(err)
+	}
+	if usage := cmd.Flags().FlagUsages(); !strings.Contains(usage, "--mode Mode   driving mode (Eco|Normal|Sport)") {
+		panic("mode.go: usage of the flag " + usage)
+	}
+
+	// The hidden command cobra runs for the shells.
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{cobra.ShellCompRequestCmd, "--mode", "e"})
+	if err := cmd.Execute(); err != nil {
+		panic(err)
+	}
+	if !strings.HasPrefix(out.String(), "Eco\tSaves power.\n:4\n") {
+		panic(fmt.Sprintf("mode.go: completion by cobra %q", out.String()))
+	}
+
+	cmd.SetArgs([]string{"--mode", "sport"})
+	if err := cmd.Execute(); err != nil || mode != Sport {
+		panic(fmt.Sprint("mode.go: --mode sport ", mode, err))
+	}
+	cmd.SetArgs([]string{"--mode", "Turbo"})
+	cmd.SetErr(new(bytes.Buffer))
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "Turbo does not belong to Mode values") {
+		panic(fmt.Sprint("mode.go: --mode Turbo ", err))
+	}
+}
+
+func ckCompletion(toComplete string, expected []string) {
+	completions, directive := ModeCompletionFunc(nil, nil, toComplete)
+	if fmt.Sprintf("%q", completions) != fmt.Sprintf("%q", expected) || directive != cobra.ShellCompDirectiveNoFileComp {
+		panic(fmt.Sprintf("mode.go: completions of %q: %q %d", toComplete, completions, directive))
+	}
+}
@@ -0,0 +1,42 @@
+// fmt.Formatter and slog.LogValuer, with values in several runs.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+type Mood int
+
+const (
+	Calm Mood = iota
+	Happy
+	Angry Mood = 10
+)
+
+func main() {
+	ck(fmt.Sprint(Happy), "Happy")
+	ck(fmt.Sprintf("%v %s %q %d %+v", Angry, Calm, Happy, Angry, Angry), `Angry Calm "Happy" 10 Angry(10)`)
+	ck(fmt.Sprintf("[%-7s] [%5d] [%x]", Calm, Happy, Angry), "[Calm   ] [    1] [a]")
+	ck(fmt.Sprintf("%v %d %f", Mood(5), Mood(5), Happy), "%!v(Mood=5) 5 %!f(Mood=1)")
+	ck(Mood(5).String(), "Mood(5)")
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("mood", "mood", Angry, "invalid", Mood(5))
+	ck(strings.Contains(buf.String(), `"mood":"Angry","invalid":"%!v(Mood=5)"`), true)
+
+	buf.Reset()
+	logger = slog.New(slog.NewTextHandler(&buf, nil))
+	logger.Info("mood", "mood", Happy)
+	ck(strings.Contains(buf.String(), "mood=Happy"), true)
+}
+
+func ck(got, want interface{}) {
+	if got != want {
+		panic(fmt.Sprintf("mood.go: got %v, want %v", got, want))
+	}
+}
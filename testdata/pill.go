@@ -0,0 +1,59 @@
+// Aliases of the same value, one of them marked canonical.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Pill int
+
+const (
+	Placebo Pill = iota
+	Aspirin
+	Ibuprofen
+	Paracetamol
+	Acetaminophen = Paracetamol //enumer:canonical
+	Tylenol       = Paracetamol
+)
+
+func main() {
+	ck(Placebo, "Placebo")
+	ck(Paracetamol, "Acetaminophen")
+	ck(Tylenol, "Acetaminophen")
+	ckPillString("Paracetamol", Paracetamol)
+	ckPillString("acetaminophen", Paracetamol)
+	ckPillString("TYLENOL", Paracetamol)
+	aliases := PillAliases(Paracetamol)
+	if len(aliases) != 2 || aliases[0] != "Paracetamol" || aliases[1] != "Tylenol" {
+		panic(fmt.Sprint("pill.go: PillAliases ", aliases))
+	}
+	if len(PillAliases(Aspirin)) != 0 {
+		panic("pill.go: Aspirin has no aliases")
+	}
+	var p Pill
+	if err := json.Unmarshal([]byte(`"Tylenol"`), &p); err != nil || p != Paracetamol {
+		panic("pill.go: UnmarshalJSON of an alias")
+	}
+	data, err := json.Marshal(Tylenol)
+	if err != nil || string(data) != `"Acetaminophen"` {
+		panic("pill.go: MarshalJSON of an alias")
+	}
+}
+
+func ck(pill Pill, str string) {
+	if fmt.Sprint(pill) != str {
+		panic("pill.go: " + str)
+	}
+}
+
+func ckPillString(str string, pill Pill) {
+	p, err := PillString(str)
+	if err != nil {
+		panic("pill.go: " + err.Error())
+	}
+	if p != pill {
+		panic("pill.go: " + str)
+	}
+}
@@ -3,7 +3,7 @@
 // license that can be found in the LICENSE file.
 
 // Enough gaps to trigger a map implementation of the method.
-// Also includes a duplicate to test that it doesn't cause problems
+// Also includes a duplicate to test that it is kept as an alias.
 
 package main
 
@@ -16,7 +16,7 @@ const (
 	p3  Prime = 3
 	p5  Prime = 5
 	p7  Prime = 7
-	p77 Prime = 7 // Duplicate; note that p77 doesn't appear below.
+	p77 Prime = 7 // Duplicate; p77 is only accepted as an alias.
 	p11 Prime = 11
 	p13 Prime = 13
 	p17 Prime = 17
@@ -0,0 +1,48 @@
+// SQL storage of the numbers of the values.
+
+package main
+
+import (
+	"fmt"
+)
+
+type Priority uint8
+
+const (
+	PriorityLow Priority = iota + 1
+	PriorityMedium
+	PriorityHigh
+)
+
+func main() {
+	ck(PriorityHigh, "PriorityHigh")
+	v, err := PriorityMedium.Value()
+	if err != nil || v != int64(2) {
+		panic(fmt.Sprint("priority.go: Value ", v))
+	}
+	ckScan(int64(3), PriorityHigh)
+	ckScan("1", PriorityLow)
+	ckScan([]byte("2"), PriorityMedium)
+	for _, value := range []interface{}{int64(0), int64(4), int64(257), "PriorityLow", 1.5} {
+		var p Priority
+		if err := p.Scan(value); err == nil {
+			panic(fmt.Sprint("priority.go: Scan of ", value))
+		}
+	}
+}
+
+func ck(priority Priority, str string) {
+	if fmt.Sprint(priority) != str {
+		panic("priority.go: " + str)
+	}
+}
+
+func ckScan(value interface{}, priority Priority) {
+	var p Priority
+	if err := p.Scan(value); err != nil {
+		panic("priority.go: " + err.Error())
+	}
+	if p != priority {
+		panic(fmt.Sprint("priority.go: Scan of ", value))
+	}
+}
@@ -0,0 +1,79 @@
+// MessagePack and CBOR numbers of a signed type, through the codecs of their
+// modules.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dmarkham/enumer/enumerrs"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type Rank int8
+
+const (
+	Private Rank = iota - 2
+	Corporal
+	Sergeant
+)
+
+type codec struct {
+	name      string
+	marshal   func(v interface{}) ([]byte, error)
+	unmarshal func(data []byte, v interface{}) error
+}
+
+var codecs = []codec{
+	{"msgpack", msgpack.Marshal, msgpack.Unmarshal},
+	{"cbor", cbor.Marshal, cbor.Unmarshal},
+}
+
+func main() {
+	for _, c := range codecs {
+		ck(c, Private, -2)
+		ck(c, Sergeant, 0)
+		ckUnmarshalError(c, 3)
+		ckUnmarshalError(c, 254) // Rank(254) is -2.
+		ckUnmarshalError(c, -300)
+		ckUnmarshalError(c, "Private")
+
+		// Without -strictmarshal, an invalid value is written but can't be read back.
+		data, err := c.marshal(Rank(5))
+		if err != nil {
+			panic(fmt.Sprintf("rank.go: %s marshaling of Rank(5): %v", c.name, err))
+		}
+		var got Rank
+		if err := c.unmarshal(data, &got); !errors.Is(err, enumerrs.ErrValueInvalid) {
+			panic(fmt.Sprintf("rank.go: %s unmarshaling of Rank(5): %v", c.name, err))
+		}
+	}
+}
+
+func ck(c codec, rank Rank, n int64) {
+	data, err := c.marshal(rank)
+	if err != nil {
+		panic(fmt.Sprintf("rank.go: %s marshaling of %s: %v", c.name, rank, err))
+	}
+	var stored int64
+	if err := c.unmarshal(data, &stored); err != nil || stored != n {
+		panic(fmt.Sprintf("rank.go: %s number of %s: %d %v", c.name, rank, stored, err))
+	}
+	var got Rank
+	if err := c.unmarshal(data, &got); err != nil || got != rank {
+		panic(fmt.Sprintf("rank.go: %s unmarshaling of %s: %v %v", c.name, rank, got, err))
+	}
+}
+
+func ckUnmarshalError(c codec, value interface{}) {
+	data, err := c.marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	var got Rank
+	if err := c.unmarshal(data, &got); err == nil {
+		panic(fmt.Sprintf("rank.go: %s unmarshaling of %v gives %v", c.name, value, got))
+	}
+}
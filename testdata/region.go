@@ -0,0 +1,50 @@
+// JSON decoding of both the names and the numbers of the values.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Region uint8
+
+const (
+	Europe Region = iota + 1
+	America
+	Asia
+)
+
+func main() {
+	ck(Asia, "Asia")
+	ckUnmarshal(`"America"`, America)
+	ckUnmarshal(`"asia"`, Asia)
+	ckUnmarshal(`1`, Europe)
+	ckUnmarshal(`3`, Asia)
+	for _, data := range []string{`0`, `4`, `-1`, `1.5`, `"Africa"`, `true`} {
+		var r Region
+		if err := json.Unmarshal([]byte(data), &r); err == nil {
+			panic("region.go: UnmarshalJSON of " + data)
+		}
+	}
+	data, err := json.Marshal(America)
+	if err != nil || string(data) != `"America"` {
+		panic("region.go: MarshalJSON")
+	}
+}
+
+func ck(region Region, str string) {
+	if fmt.Sprint(region) != str {
+		panic("region.go: " + str)
+	}
+}
+
+func ckUnmarshal(data string, region Region) {
+	var r Region
+	if err := json.Unmarshal([]byte(data), &r); err != nil {
+		panic("region.go: " + err.Error())
+	}
+	if r != region {
+		panic("region.go: UnmarshalJSON of " + data)
+	}
+}
@@ -0,0 +1,45 @@
+// Marshaling methods failing on values that are not part of the enum.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/dmarkham/enumer/enumerrs"
+)
+
+type Shape int
+
+const (
+	Circle Shape = iota
+	Square
+	Triangle
+)
+
+func main() {
+	ck(Square, "Square")
+	data, err := json.Marshal(Triangle)
+	if err != nil || string(data) != `"Triangle"` {
+		panic("shape.go: MarshalJSON of a value")
+	}
+	if _, err := json.Marshal(Shape(42)); !errors.Is(err, enumerrs.ErrValueInvalid) {
+		panic(fmt.Sprint("shape.go: MarshalJSON of Shape(42): ", err))
+	}
+	if _, err := Shape(-1).MarshalText(); !errors.Is(err, enumerrs.ErrValueInvalid) {
+		panic(fmt.Sprint("shape.go: MarshalText of Shape(-1): ", err))
+	}
+	if _, err := Shape(3).Value(); !errors.Is(err, enumerrs.ErrValueInvalid) {
+		panic(fmt.Sprint("shape.go: Value of Shape(3): ", err))
+	}
+	if v, err := Circle.Value(); err != nil || v != "Circle" {
+		panic("shape.go: Value of a value")
+	}
+}
+
+func ck(shape Shape, str string) {
+	if fmt.Sprint(shape) != str {
+		panic("shape.go: " + str)
+	}
+}
@@ -0,0 +1,88 @@
+// Null wrapper telling absent values from the zero value.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+)
+
+type Size int
+
+const (
+	Small Size = iota
+	Medium
+	Large
+)
+
+type shirt struct {
+	Size NullSize `json:"size"`
+}
+
+func main() {
+	ck(Large, "Large")
+
+	var s shirt
+	if err := json.Unmarshal([]byte(`{"size":null}`), &s); err != nil || s.Size.Valid {
+		panic("size.go: UnmarshalJSON of null")
+	}
+	if err := json.Unmarshal([]byte(`{"size":"Small"}`), &s); err != nil || !s.Size.Valid || s.Size.Size != Small {
+		panic("size.go: UnmarshalJSON of Small")
+	}
+	if err := json.Unmarshal([]byte(`{"size":"Huge"}`), &s); err == nil {
+		panic("size.go: UnmarshalJSON of an unknown name")
+	}
+	ckJSON(shirt{}, `{"size":null}`)
+	ckJSON(shirt{NullSize{Medium, true}}, `{"size":"Medium"}`)
+
+	var n NullSize
+	if err := n.Scan(nil); err != nil || n.Valid {
+		panic("size.go: Scan of NULL")
+	}
+	if err := n.Scan("Large"); err != nil || !n.Valid || n.Size != Large {
+		panic("size.go: Scan of Large")
+	}
+	if v, err := (NullSize{}).Value(); err != nil || v != nil {
+		panic("size.go: Value of NULL")
+	}
+	if v, err := n.Value(); err != nil || v != "Large" {
+		panic("size.go: Value of Large")
+	}
+	if n.String() != "Large" {
+		panic("size.go: String of NullSize")
+	}
+	if s := fmt.Sprintf("%v %s %q %d", NullSize{}, NullSize{Small, false}, NullSize{}, NullSize{Size(9), false}); s != `null null "null" %!d(string=null)` {
+		panic("size.go: formatting a null: " + s)
+	}
+	if s := fmt.Sprintf("%+v %d %s", n, n, NullSize{Size(9), true}); s != "Large(2) 2 %!s(Size=9)" {
+		panic("size.go: formatting a NullSize: " + s)
+	}
+
+	ckLog(NullSize{}, `"size":null`)
+	ckLog(NullSize{Size(9), false}, `"size":null`)
+	ckLog(n, `"size":"Large"`)
+	ckLog(NullSize{Size(9), true}, `"size":"%!v(Size=9)"`)
+}
+
+func ckLog(n NullSize, attr string) {
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("shirt", "size", n)
+	if !bytes.Contains(buf.Bytes(), []byte(attr)) {
+		panic("size.go: LogValue of " + n.String() + ": " + buf.String())
+	}
+}
+
+func ck(size Size, str string) {
+	if fmt.Sprint(size) != str {
+		panic("size.go: " + str)
+	}
+}
+
+func ckJSON(s shirt, str string) {
+	data, err := json.Marshal(s)
+	if err != nil || string(data) != str {
+		panic(fmt.Sprint("size.go: MarshalJSON ", string(data), err))
+	}
+}
@@ -0,0 +1,51 @@
+// Case sensitive parsing.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Status int
+
+const (
+	Active Status = iota
+	Inactive
+)
+
+func main() {
+	ck(Active, "Active")
+	ckStatusString("Inactive", Inactive)
+	for _, s := range []string{"active", "ACTIVE", "inactive"} {
+		if _, err := StatusString(s); err == nil {
+			panic("status.go: " + s + " should not parse")
+		}
+		if _, err := StatusStringStrict(s); err == nil {
+			panic("status.go: " + s + " should not parse strictly")
+		}
+	}
+	var st Status
+	if err := json.Unmarshal([]byte(`"active"`), &st); err == nil {
+		panic("status.go: UnmarshalJSON should be case sensitive")
+	}
+	if err := json.Unmarshal([]byte(`"Inactive"`), &st); err != nil || st != Inactive {
+		panic("status.go: UnmarshalJSON")
+	}
+}
+
+func ck(status Status, str string) {
+	if fmt.Sprint(status) != str {
+		panic("status.go: " + str)
+	}
+}
+
+func ckStatusString(str string, status Status) {
+	s, err := StatusString(str)
+	if err != nil {
+		panic("status.go: " + err.Error())
+	}
+	if s != status {
+		panic("status.go: " + str)
+	}
+}
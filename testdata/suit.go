@@ -0,0 +1,63 @@
+// pgx codec of a signed type, exercised through a pgtype.Map without a server.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dmarkham/enumer/enumerrs"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Suit int8
+
+const (
+	Hearts Suit = iota - 2
+	Diamonds
+	Clubs
+	Spades
+)
+
+// suitOID stands for the OID Postgres gives to the suit type.
+const suitOID = 100000
+
+func main() {
+	ck(Hearts, "Hearts")
+
+	m := pgtype.NewMap()
+	RegisterSuitPgType(m, "suit", suitOID)
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		buf, err := m.Encode(suitOID, format, Hearts, nil)
+		if err != nil || string(buf) != "Hearts" {
+			panic(fmt.Sprint("suit.go: Encode of Hearts: ", string(buf), err))
+		}
+		if _, err := m.Encode(suitOID, format, Suit(5), nil); !errors.Is(err, enumerrs.ErrValueInvalid) {
+			panic(fmt.Sprint("suit.go: Encode of Suit(5): ", err))
+		}
+
+		var s Suit
+		if err := m.Scan(suitOID, format, []byte("Diamonds"), &s); err != nil || s != Diamonds {
+			panic(fmt.Sprint("suit.go: Scan of Diamonds: ", s, err))
+		}
+		if err := m.Scan(suitOID, format, []byte("spades"), &s); err != nil || s != Spades {
+			panic(fmt.Sprint("suit.go: Scan of spades: ", s, err))
+		}
+		if err := m.Scan(suitOID, format, []byte("Jokers"), &s); !errors.Is(err, enumerrs.ErrValueInvalid) {
+			panic(fmt.Sprint("suit.go: Scan of an unknown name: ", err))
+		}
+		if err := m.Scan(suitOID, format, nil, &s); err == nil {
+			panic("suit.go: Scan of NULL")
+		}
+
+		if v, err := (_SuitPgCodec{}).DecodeValue(m, suitOID, format, []byte("Clubs")); err != nil || v != Clubs {
+			panic(fmt.Sprint("suit.go: DecodeValue of Clubs: ", v, err))
+		}
+	}
+}
+
+func ck(suit Suit, str string) {
+	if fmt.Sprint(suit) != str {
+		panic("suit.go: " + str)
+	}
+}
@@ -0,0 +1,55 @@
+// Names and aliases given by directives.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Task int
+
+const (
+	TaskTodo Task = iota
+	//enumer:name "in-progress"
+	//enumer:alias "in_progress", "wip"
+	TaskInProgress
+	TaskDone //enumer:alias "finished"
+)
+
+func main() {
+	ck(TaskTodo, "todo")
+	ck(TaskInProgress, "in-progress")
+	ck(TaskDone, "done")
+	ckTaskString("in-progress", TaskInProgress)
+	ckTaskString("in_progress", TaskInProgress)
+	ckTaskString("WIP", TaskInProgress)
+	ckTaskString("finished", TaskDone)
+	if _, err := TaskString("InProgress"); err == nil {
+		panic("task.go: the constant name is not a name of the value")
+	}
+	var task Task
+	if err := json.Unmarshal([]byte(`"wip"`), &task); err != nil || task != TaskInProgress {
+		panic("task.go: UnmarshalJSON of an alias")
+	}
+	data, err := json.Marshal(TaskInProgress)
+	if err != nil || string(data) != `"in-progress"` {
+		panic("task.go: MarshalJSON")
+	}
+}
+
+func ck(task Task, str string) {
+	if fmt.Sprint(task) != str {
+		panic("task.go: " + str)
+	}
+}
+
+func ckTaskString(str string, task Task) {
+	t, err := TaskString(str)
+	if err != nil {
+		panic("task.go: " + err.Error())
+	}
+	if t != task {
+		panic("task.go: " + str)
+	}
+}
@@ -0,0 +1,59 @@
+// Decoding of environment variables for envconfig and caarlos0/env.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Tier int
+
+const (
+	Free Tier = iota
+	Pro
+	Enterprise
+)
+
+// decoder is the interface of envconfig.
+type decoder interface {
+	Decode(value string) error
+}
+
+// parserFunc is the type of the functions of the FuncMap of caarlos0/env.
+type parserFunc func(v string) (interface{}, error)
+
+var (
+	_ decoder    = new(Tier)
+	_ parserFunc = TierEnvParser
+)
+
+func main() {
+	ck(Pro, "Pro")
+
+	var t Tier
+	if err := t.Decode("Enterprise"); err != nil || t != Enterprise {
+		panic(fmt.Sprint("tier.go: Decode of Enterprise ", err))
+	}
+	err := t.Decode("enterprise")
+	if err == nil || !strings.HasSuffix(err.Error(), ", allowed values: Free, Pro, Enterprise") {
+		panic(fmt.Sprint("tier.go: Decode of an unknown name ", err))
+	}
+	if t != Enterprise {
+		panic("tier.go: Decode of an unknown name changed the value")
+	}
+
+	v, err := TierEnvParser("Pro")
+	if err != nil || v != Pro {
+		panic(fmt.Sprint("tier.go: TierEnvParser of Pro ", v, err))
+	}
+	if _, err := TierEnvParser("Gold"); err == nil {
+		panic("tier.go: TierEnvParser of an unknown name")
+	}
+}
+
+func ck(tier Tier, str string) {
+	if fmt.Sprint(tier) != str {
+		panic("tier.go: " + str)
+	}
+}
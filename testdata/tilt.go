@@ -0,0 +1,59 @@
+// Varint binary marshaling of a signed type with negative values.
+
+package main
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+)
+
+type Tilt int8
+
+const (
+	Down Tilt = iota - 2
+	Low
+	Level
+	Up Tilt = 100
+)
+
+var (
+	_ encoding.BinaryMarshaler   = Level
+	_ encoding.BinaryUnmarshaler = new(Tilt)
+	_ encoding.BinaryAppender    = Level
+)
+
+func main() {
+	ck(Down, "Down")
+	ck(Up, "Up")
+
+	for _, tilt := range TiltValues() {
+		data, err := tilt.MarshalBinary()
+		if err != nil {
+			panic(fmt.Sprint("tilt.go: MarshalBinary ", tilt, err))
+		}
+		var got Tilt
+		if err := got.UnmarshalBinary(data); err != nil || got != tilt {
+			panic(fmt.Sprint("tilt.go: UnmarshalBinary ", tilt, err))
+		}
+	}
+	if data, _ := Down.MarshalBinary(); !bytes.Equal(data, []byte{3}) {
+		panic(fmt.Sprintf("tilt.go: MarshalBinary of Down is %x", data))
+	}
+	if data, _ := Up.AppendBinary([]byte{0xff}); !bytes.Equal(data, []byte{0xff, 0xc8, 0x01}) {
+		panic(fmt.Sprintf("tilt.go: AppendBinary of Up is %x", data))
+	}
+
+	var t Tilt
+	for _, data := range [][]byte{{}, {0x04}, {0x80}, {0x00, 0x00}, {0x90, 0x03}} {
+		if err := t.UnmarshalBinary(data); err == nil {
+			panic(fmt.Sprintf("tilt.go: UnmarshalBinary of %x", data))
+		}
+	}
+}
+
+func ck(tilt Tilt, str string) {
+	if fmt.Sprint(tilt) != str {
+		panic("tilt.go: " + str)
+	}
+}
@@ -0,0 +1,85 @@
+// GraphQL marshaling with the context interfaces of gqlgen.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/dmarkham/enumer/enumerrs"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+type Urgency int8
+
+const (
+	Low Urgency = iota - 1
+	Normal
+	High
+)
+
+var (
+	_ graphql.ContextMarshaler   = Low
+	_ graphql.ContextUnmarshaler = new(Urgency)
+)
+
+func main() {
+	ctx := context.Background()
+
+	ckMarshal(ctx, Low, `"Low"`)
+	ckMarshal(ctx, High, `"High"`)
+	ckMarshalError(ctx, Urgency(2))
+	ckMarshalError(ctx, Urgency(-2))
+
+	ckUnmarshal(ctx, "Low", Low)
+	ckUnmarshal(ctx, "high", High)
+	ckUnmarshalError(ctx, "Urgent")
+	ckUnmarshalError(ctx, -1)
+}
+
+func ckMarshal(ctx context.Context, u Urgency, str string) {
+	var buf bytes.Buffer
+	if err := u.MarshalGQLContext(ctx, &buf); err != nil || buf.String() != str {
+		panic(fmt.Sprint("urgency.go: MarshalGQLContext of ", u, ": ", buf.String(), err))
+	}
+}
+
+func ckMarshalError(ctx context.Context, u Urgency) {
+	var buf bytes.Buffer
+	err := u.MarshalGQLContext(ctx, &buf)
+	if !errors.Is(err, enumerrs.ErrValueInvalid) || buf.Len() != 0 {
+		panic(fmt.Sprint("urgency.go: MarshalGQLContext of ", u, ": ", buf.String(), err))
+	}
+	ckAllowedValues(err)
+}
+
+func ckUnmarshal(ctx context.Context, value interface{}, u Urgency) {
+	var got Urgency
+	if err := got.UnmarshalGQLContext(ctx, value); err != nil || got != u {
+		panic(fmt.Sprint("urgency.go: UnmarshalGQLContext of ", value, ": ", got, err))
+	}
+}
+
+func ckUnmarshalError(ctx context.Context, value interface{}) {
+	got := Normal
+	err := got.UnmarshalGQLContext(ctx, value)
+	if err == nil || got != Normal {
+		panic(fmt.Sprint("urgency.go: UnmarshalGQLContext of ", value, " gives ", got))
+	}
+	ckAllowedValues(err)
+}
+
+// ckAllowedValues checks err is a GraphQL error listing the names.
+func ckAllowedValues(err error) {
+	var gqlErr *gqlerror.Error
+	if !errors.As(err, &gqlErr) || !strings.HasSuffix(gqlErr.Message, "allowed values: Low, Normal, High") {
+		panic(fmt.Sprint("urgency.go: GraphQL error ", err))
+	}
+	if names, ok := gqlErr.Extensions["allowedValues"].([]string); !ok || len(names) != 3 {
+		panic(fmt.Sprint("urgency.go: allowedValues extension of ", err))
+	}
+}
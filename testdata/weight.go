@@ -0,0 +1,58 @@
+// Null wrapper decoded by yaml.v3, which doesn't call UnmarshalYAML for a null.
+
+package main
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Weight int
+
+const (
+	Light Weight = iota
+	Heavy
+)
+
+type parcel struct {
+	Weight NullWeight  `yaml:"weight"`
+	Max    *NullWeight `yaml:"max"`
+}
+
+func main() {
+	ck(Heavy, "Heavy")
+
+	var p parcel
+	if err := yaml.Unmarshal([]byte("weight: null\nmax: null\n"), &p); err != nil || p.Weight.Valid || p.Max != nil {
+		panic("weight.go: UnmarshalYAML of null")
+	}
+	if err := yaml.Unmarshal([]byte("weight: Heavy\nmax: Light\n"), &p); err != nil || !p.Weight.Valid || p.Weight.Weight != Heavy || !p.Max.Valid || p.Max.Weight != Light {
+		panic("weight.go: UnmarshalYAML of values")
+	}
+	if err := yaml.Unmarshal([]byte("max: ~\n"), &p); err != nil || p.Max != nil {
+		panic("weight.go: UnmarshalYAML of null into a pointer")
+	}
+	// yaml.v3 leaves a value that is already valid unchanged on null.
+	if err := yaml.Unmarshal([]byte("weight: null\n"), &p); err != nil || !p.Weight.Valid || p.Weight.Weight != Heavy {
+		panic("weight.go: UnmarshalYAML of null into a valid value")
+	}
+	if err := yaml.Unmarshal([]byte("weight: Huge\n"), &p); err == nil {
+		panic("weight.go: UnmarshalYAML of an unknown name")
+	}
+	ckYAML(parcel{}, "weight: null\nmax: null\n")
+	ckYAML(parcel{Weight: NullWeight{Light, true}}, "weight: Light\nmax: null\n")
+}
+
+func ck(weight Weight, str string) {
+	if fmt.Sprint(weight) != str {
+		panic("weight.go: " + str)
+	}
+}
+
+func ckYAML(p parcel, str string) {
+	data, err := yaml.Marshal(p)
+	if err != nil || string(data) != str {
+		panic(fmt.Sprint("weight.go: MarshalYAML ", string(data), err))
+	}
+}
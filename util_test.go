@@ -54,7 +54,7 @@ Outer:
 	for n, test := range splitTests {
 		values := make([]Value, len(test.input))
 		for i, v := range test.input {
-			values[i] = Value{"", "", v, test.signed, fmt.Sprint(v)}
+			values[i] = Value{value: v, signed: test.signed, str: fmt.Sprint(v)}
 		}
 		runs := splitIntoRuns(values)
 		if len(runs) != len(test.output) {
@@ -75,3 +75,32 @@ Outer:
 		}
 	}
 }
+
+var reserveDroppedTests = []struct {
+	values map[string]int64
+	err    string
+}{
+	// A value dropped, another added.
+	{map[string]int64{"COLOR_RED": 1, "COLOR_BLUE": 3}, ""},
+	// A kept name with another number.
+	{map[string]int64{"COLOR_RED": 2}, "COLOR_RED of type Color was 1 and can't become 2"},
+	// A reserved number used again.
+	{map[string]int64{"COLOR_RED": 1, "COLOR_BLUE": 5}, "COLOR_BLUE of type Color uses the reserved number 5"},
+	// A reserved name used again.
+	{map[string]int64{"COLOR_RED": 1, "COLOR_PINK": 3}, "COLOR_PINK of type Color uses a reserved name"},
+}
+
+func TestReserveDropped(t *testing.T) {
+	for n, test := range reserveDroppedTests {
+		prev := &protoEnumDef{
+			values:   map[string]int64{"COLOR_RED": 1, "COLOR_GREEN": 2},
+			ranges:   [][2]int64{{4, 6}},
+			reserved: []string{"COLOR_PINK"},
+		}
+		def := &protoEnumDef{values: test.values}
+		err := def.reserveDropped(prev, "Color")
+		if test.err == "" && err != nil || test.err != "" && (err == nil || err.Error() != test.err) {
+			t.Errorf("#%d: got error %v; expected %q", n, err, test.err)
+		}
+	}
+}
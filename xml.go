@@ -0,0 +1,47 @@
+package main
+
+// Arguments to format are: [1]: type name [2]: name of the function decoding strings
+// [3]: strict marshaling guard of MarshalXMLAttr [4]: strict marshaling guard of MarshalXML
+const xmlMethods = `
+// MarshalXMLAttr implements the xml.MarshalerAttr interface for %[1]s
+func (i %[1]s) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+%[3]s	return xml.Attr{Name: name, Value: i.String()}, nil
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface for %[1]s
+func (i *%[1]s) UnmarshalXMLAttr(attr xml.Attr) error {
+	val, err := %[2]s(attr.Value)
+	if err != nil {
+		return fmt.Errorf("attribute %%s: %%w", attr.Name.Local, err)
+	}
+	*i = val
+	return nil
+}
+
+// MarshalXML implements the xml.Marshaler interface for %[1]s
+func (i %[1]s) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+%[4]s	return enc.EncodeElement(i.String(), start)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface for %[1]s
+func (i *%[1]s) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := dec.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+
+	val, err := %[2]s(s)
+	if err != nil {
+		return fmt.Errorf("element %%s: %%w", start.Name.Local, err)
+	}
+	*i = val
+	return nil
+}
+`
+
+// buildXMLMethods generates the XML marshaling methods of attributes and
+// elements, which write the names and decode them as the text methods do.
+func (g *Generator) buildXMLMethods(typeName string, decode string, opts generateOptions) {
+	g.Printf(xmlMethods, typeName, decode,
+		strictMarshalGuard(typeName, "return xml.Attr{}, %s", opts), strictMarshalGuard(typeName, "return %s", opts))
+}